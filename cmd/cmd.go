@@ -1 +1,377 @@
+// Package cmd implements the crontable command line: a tree of subcommands that read cron expressions and crontab files,
+// and report on them as text, JSON or YAML.
 package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"strings"
+)
+
+// Exit statuses of the crontable command
+const (
+	// ExitValid is returned when every schedule read is valid, and the command did what it was asked
+	ExitValid = 0
+	// ExitInvalid is returned when a schedule read is invalid, or the command could not do what it was asked
+	ExitInvalid = 1
+	// ExitUsage is returned when the command line itself is wrong: an unknown command, flag or output format
+	ExitUsage = 2
+)
+
+// Output formats accepted by --output
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// command is a subcommand of crontable
+type command struct {
+	name string
+	// args is the synopsis of the arguments the command takes after its flags
+	args    string
+	summary string
+	run     func(e *env, args []string) error
+}
+
+// commands lists every subcommand in the order the help text shows them
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{name: "explain", args: "[file|-|expression...]", summary: "describe when each schedule runs, in words", run: runExplain},
+		{name: "validate", args: "[file|-|expression...]", summary: "check schedules for errors", run: runValidate},
+		{name: "lint", args: "[file|-|expression...]", summary: "check schedules for errors and likely mistakes", run: runLint},
+		{name: "next", args: "[file|-|expression...]", summary: "list the upcoming run times of each schedule", run: runNext},
+		{name: "fmt", args: "[file|-|expression...]", summary: "rewrite schedules in a consistent layout", run: runFmt},
+		{name: "convert", args: "[file|-|expression...]", summary: "rewrite schedules for another cron implementation", run: runConvert},
+		{name: "diff", args: "<old> <new>", summary: "compare the run times of two schedules or crontab files", run: runDiff},
+		{name: "overlaps", args: "[file|-|expression...]", summary: "find entries firing within the same minute", run: runOverlaps},
+	}
+}
+
+// env holds the streams a command reads from and writes to
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// stdinRead is set once standard input has been read, as it can only be read once
+	stdinRead bool
+}
+
+// usageError is a command line that could not be made sense of, exiting with ExitUsage
+type usageError struct {
+	msg string
+	// reported is set when the message and usage were already written, as the flag package does for flags it cannot parse
+	reported bool
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef formats a usageError
+func usagef(format string, a ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// errInvalid is returned by commands that ran to completion but found invalid schedules, having already reported them. It exits with ExitInvalid.
+var errInvalid = errors.New("invalid schedules found")
+
+// Execute runs the crontable command line passed in, without the program name, against the standard streams, returning the status to exit with
+func Execute(args []string) int {
+	return execute(&env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}, args)
+}
+
+// execute runs the crontable command line passed in against the streams of e, returning the status to exit with
+func execute(e *env, args []string) int {
+	if len(args) == 0 {
+		e.usage(e.stderr)
+		return ExitUsage
+	}
+	name, rest := args[0], args[1:]
+	switch {
+	case name == "help" || name == "-h" || name == "-help" || name == "--help":
+		if len(rest) == 0 {
+			e.usage(e.stdout)
+			return ExitValid
+		}
+		c := lookup(rest[0])
+		if c == nil {
+			fmt.Fprintf(e.stderr, "crontable: unknown command %q\n", rest[0])
+			return ExitUsage
+		}
+		return e.finish(c, c.run(e, []string{"-h"}))
+	case lookup(name) != nil:
+		c := lookup(name)
+		return e.finish(c, c.run(e, rest))
+	case strings.HasPrefix(name, "-") || isFile(name):
+		// before subcommands, crontable took a crontab file alone and explained it
+		c := lookup("explain")
+		return e.finish(c, c.run(e, args))
+	}
+	fmt.Fprintf(e.stderr, "crontable: unknown command %q\nRun 'crontable help' for usage.\n", name)
+	return ExitUsage
+}
+
+// finish reports the error a command returned, and turns it into the status to exit with
+func (e *env) finish(c *command, err error) int {
+	var usage *usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return ExitValid
+	case errors.As(err, &usage) && usage.reported:
+		return ExitUsage
+	case errors.As(err, &usage):
+		fmt.Fprintf(e.stderr, "crontable %s: %s\nRun 'crontable help %s' for usage.\n", c.name, usage.msg, c.name)
+		return ExitUsage
+	case errors.Is(err, errInvalid):
+		return ExitInvalid
+	}
+	fmt.Fprintf(e.stderr, "crontable %s: %s\n", c.name, err.Error())
+	return ExitInvalid
+}
+
+// usage writes the help text listing every command
+func (e *env) usage(w io.Writer) {
+	fmt.Fprintln(w, "crontable reads cron expressions and crontab files, and reports on them.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  crontable <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Each command reads the crontab files named by its arguments, \"-\" for standard input, and the cron expressions given with -e.")
+	fmt.Fprintln(w, "An argument naming no file is read as an expression when it holds spaces or starts with \"@\", and standard input is read")
+	fmt.Fprintln(w, "when there are no arguments. Run 'crontable help <command>' for the flags of a command.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit status is 0 when every schedule is valid, 1 when one is not or the command fails, and 2 for usage errors.")
+}
+
+// lookup returns the command of the name passed in, or nil when there is none
+func lookup(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// options holds the flags every command accepts
+type options struct {
+	output      string
+	dialectName string
+	dialect     *reader.Dialect
+	exprs       stringList
+}
+
+// stringList is a flag that may be given many times, collecting every value given
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// flagSet returns the flag set of the command, with the flags every command accepts registered into the options returned
+func (e *env) flagSet(c *command) (*flag.FlagSet, *options) {
+	o := &options{}
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.StringVar(&o.output, "output", OutputText, "output format: text, json or yaml")
+	fs.StringVar(&o.dialectName, "dialect", reader.Vixie.Name, "cron implementation to read schedules as: vixie, cronie, quartz, aws or kubernetes")
+	fs.Var(&o.exprs, "e", "cron expression to read, such as \"*/5 * * * *\"; may be given many times")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: crontable %s [flags] %s\n\n%s.\n\nFlags:\n", c.name, c.args, strings.ToUpper(c.summary[:1])+c.summary[1:])
+		fs.PrintDefaults()
+	}
+	return fs, o
+}
+
+// parse parses the command line of a command, checking the flags every command accepts. Errors are usage errors, but for a request for help.
+func (o *options) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error(), reported: true}
+	}
+	switch o.output {
+	case OutputText, OutputJSON, OutputYAML:
+	default:
+		return usagef("unknown output format %q, expected text, json or yaml", o.output)
+	}
+	d, err := reader.LookupDialect(o.dialectName)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	o.dialect = d
+	return nil
+}
+
+// input is a crontab read from a file or standard input, or a cron expression given on the command line, held as a crontab of a single entry
+type input struct {
+	// Name is the path of the file read, "-" for standard input, or the expression itself
+	Name string
+	// Inline is set for an expression given on the command line
+	Inline bool
+	// Text is what was read, for files and standard input
+	Text []byte
+	Tab  *reader.Crontab
+	// Err reports the lines of the crontab that could not be read as entries
+	Err error
+}
+
+// inputs reads every input of a command: the expressions of its -e flags, then the crontab files named by its arguments, with "-" naming standard input.
+// Arguments naming no file are read as expressions when they hold whitespace or start with "@", so that a quoted expression can be given directly.
+// Standard input is read when there are no inputs otherwise. Inputs that cannot be read are reported and left out, reporting false.
+func (e *env) inputs(fs *flag.FlagSet, o *options) ([]*input, bool) {
+	args := fs.Args()
+	if len(args) == 0 && len(o.exprs) == 0 {
+		args = []string{"-"}
+	}
+	ins := make([]*input, 0, len(o.exprs)+len(args))
+	for _, expr := range o.exprs {
+		ins = append(ins, inline(expr, o.dialect))
+	}
+	ok := true
+	for _, arg := range args {
+		in, err := e.load(arg, o)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "%s: %s\n", arg, err.Error())
+			ok = false
+			continue
+		}
+		ins = append(ins, in)
+	}
+	return ins, ok
+}
+
+// load reads the argument of a command: standard input for "-", the crontab file it names, or the cron expression it holds. Crontabs are read in the dialect
+// of the options, in the format their location calls for.
+func (e *env) load(arg string, o *options) (*input, error) {
+	switch {
+	case arg == "-":
+		if e.stdinRead {
+			return nil, errors.New("standard input can only be read once")
+		}
+		e.stdinRead = true
+		text, err := io.ReadAll(e.stdin)
+		if err != nil {
+			return nil, err
+		}
+		return parseInput("-", text, reader.UserCrontab, o.dialect)
+	case isFile(arg):
+		text, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, err
+		}
+		return parseInput(arg, text, reader.KindOf(arg), o.dialect)
+	case strings.ContainsAny(arg, " \t") || strings.HasPrefix(strings.TrimSpace(arg), "@"):
+		return inline(arg, o.dialect), nil
+	}
+	_, err := os.Stat(arg)
+	if err == nil {
+		err = errors.New("is a directory")
+	}
+	return nil, err
+}
+
+// inline holds a cron expression given on the command line as a crontab of a single entry
+func inline(arg string, d *reader.Dialect) *input {
+	expr := strings.Join(strings.Fields(arg), " ")
+	tab := &reader.Crontab{Kind: reader.UserCrontab, Dialect: d, Entries: []reader.Entry{{Line: 1, Schedule: reader.CronRead(expr)}}}
+	return &input{Name: expr, Inline: true, Tab: tab}
+}
+
+// parseInput parses the text of a crontab read from the place named. It errors when the text cannot be read as lines at all, such as for a line too long.
+func parseInput(name string, text []byte, kind int, d *reader.Dialect) (*input, error) {
+	tab, err := reader.ParseCrontabDialect(bytes.NewReader(text), kind, d)
+	if tab == nil {
+		return nil, err
+	}
+	return &input{Name: name, Text: text, Tab: tab, Err: err}, nil
+}
+
+// isFile reports whether path names a file that can be read
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// document is the JSON and YAML output of a command: its results for each input, under the reader.SchemaVersion they are written in
+type document struct {
+	Version int         `json:"version" yaml:"version"`
+	Inputs  interface{} `json:"inputs" yaml:"inputs"`
+}
+
+// documentOf holds the results of a command in a document of the current schema version
+func documentOf(inputs interface{}) document {
+	return document{Version: reader.SchemaVersion, Inputs: inputs}
+}
+
+// report holds the results of a command for one of its inputs
+type report struct {
+	Input   string      `json:"input" yaml:"input"`
+	Entries interface{} `json:"entries" yaml:"entries"`
+}
+
+// header writes the name of the input ahead of its results in text output, when a command reads more than one input
+func header(w io.Writer, ins []*input, in *input) {
+	if len(ins) > 1 {
+		fmt.Fprintf(w, "==> %s <==\n", in.Name)
+	}
+}
+
+// write writes the result of a command in the output format of the options: v encoded as JSON or YAML, or the text written by text
+func (e *env) write(o *options, v interface{}, text func(w io.Writer) error) error {
+	switch o.output {
+	case OutputJSON:
+		enc := json.NewEncoder(e.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case OutputYAML:
+		enc := yaml.NewEncoder(e.stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return text(e.stdout)
+}
+
+// entryText writes an entry the way it reads in its crontab: its schedule, then its user and command where it has them
+func entryText(entry *reader.Entry) string {
+	parts := []string{entry.Schedule.String()}
+	if entry.User != "" {
+		parts = append(parts, entry.User)
+	}
+	if entry.Command != "" {
+		parts = append(parts, entry.Command)
+	}
+	return strings.Join(parts, " ")
+}
+
+// location names an entry of an input for messages: the input with the line of the entry, or the expression alone
+func location(in *input, entry *reader.Entry) string {
+	if in.Inline {
+		return fmt.Sprintf("%q", in.Name)
+	}
+	return fmt.Sprintf("%s:%d", in.Name, entry.Line)
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type Commands struct {
+	suite.Suite
+	dir string
+}
+
+// run is a command line along with the standard input given to it, and the exit status and output expected of it
+type run struct {
+	args   []string
+	stdin  string
+	status int
+	stdout []string
+	stderr []string
+}
+
+var CrontabTestFile = `SHELL=/bin/sh
+# backup
+0   2 * * *    /bin/backup
+30 8 * * 1-5 /bin/report
+`
+
+func (c *Commands) SetupTest() {
+	c.dir = c.T().TempDir()
+	c.Require().NoError(os.WriteFile(filepath.Join(c.dir, "crontab"), []byte(CrontabTestFile), 0o600))
+}
+
+// execute runs a command line against buffers, returning its exit status, standard output and standard error
+func (c *Commands) execute(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := execute(&env{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr}, args)
+	return status, stdout.String(), stderr.String()
+}
+
+// TestExitStatus tests that commands exit 0 for valid schedules, 1 for invalid ones, and 2 for usage errors
+func (c *Commands) TestExitStatus() {
+	tab := filepath.Join(c.dir, "crontab")
+	long := "0 9 * * * /bin/echo " + strings.Repeat("x", 70000) + "\n"
+	runs := []run{
+		{args: []string{"validate", tab}, stdout: []string{tab + ":3: ok", tab + ":4: ok"}},
+		{args: []string{"validate", "0 24 * * *"}, status: ExitInvalid, stdout: []string{"number 24 not within acceptable bounds 0-23", "  ^^"}},
+		{args: []string{"validate"}, stdin: "0 9 * * * /bin/true\n", stdout: []string{"-:1: ok"}},
+		{args: []string{"lint", "0 9 * * *"}, stdout: []string{"ok"}},
+		{args: []string{"lint", "0 9 1 * MON"}, status: ExitInvalid, stdout: []string{"warning: dayOfTheWeek: both day fields are restricted"}},
+		{args: []string{"validate", "--dialect", "quartz", "0 0 9 ? * MON"}, stdout: []string{"ok"}},
+		{args: []string{"explain", "@daily"}, stdout: []string{"expression: @daily", "Every day at midnight"}},
+		{args: []string{"explain", tab}, stdout: []string{"entry on line 3: 0 2 * * * /bin/backup"}},
+		{args: []string{"explain", "--lang", "de", "0 9 * * 1-5"}, stdout: []string{"Um 09:00, Montag bis Freitag"}},
+		{args: []string{"explain", "--lang", "pt-BR", "@daily"}, stdout: []string{"Todo dia à meia-noite"}},
+		{args: []string{tab}, stdout: []string{"entry on line 4: 30 8 * * 1-5 /bin/report"}},
+		{args: []string{"fmt", tab}, stdout: []string{"# backup\n0  2 * * *   /bin/backup\n30 8 * * 1-5 /bin/report\n"}},
+		{args: []string{"fmt", "--check", tab}, status: ExitInvalid, stdout: []string{tab}},
+		{args: []string{"fmt", "-"}, stdin: "0 2 * * * /bin/a\n*/0 * * * *   broken\n", status: ExitInvalid,
+			stdout: []string{"0 2 * * * /bin/a\n*/0 * * * *   broken\n"}, stderr: []string{"-:2: error: minute: step 0 not within acceptable bounds 1-60"}},
+		{args: []string{"fmt", "--compress", "--names", "0,15,30,45 * * * 1,2,3,4,5"}, stdout: []string{"*/15 * * * MON-FRI\n"}},
+		{args: []string{"convert", "--to", "quartz", "*/15 9-17 * * MON-FRI"}, stdout: []string{"0 */15 9-17 ? * MON-FRI\n"}},
+		{args: []string{"convert", "--to", "quartz", "0 9 1 * MON"}, status: ExitInvalid, stderr: []string{"cannot restrict both day fields"}},
+		{args: []string{"next", "--from", "2026-10-17T10:00:00Z", "--tz", "UTC", "--count", "1", "0 9 * * *"}, stdout: []string{"Sun 2026-10-18 09:00:00 UTC"}},
+		{args: []string{"diff", "--from", "2026-10-17T00:00:00Z", "--tz", "UTC", "*/15 * * * *", "0,15,30,45 * * * *"}, stdout: []string{"the schedules are equivalent"}},
+		{args: []string{"diff", "--from", "2026-10-17T00:00:00Z", "--until", "2026-10-17T01:00:00Z", "--tz", "UTC", "0 9 * * *", "0 9 * * 1-5"},
+			status: ExitInvalid, stdout: []string{"the old schedule fires at every time the new one does"}},
+		{args: []string{"diff", "--from", "2026-10-17T00:00:00Z", "--until", "2026-10-18T00:00:00Z", "--tz", "UTC", "0 9 * * *", "0 10 * * *"},
+			status: ExitInvalid, stdout: []string{"- Sat 2026-10-17 09:00:00 UTC\n+ Sat 2026-10-17 10:00:00 UTC\nthe schedules differ\n"}},
+		{args: []string{"overlaps", "--from", "2026-10-17T00:00:00Z", "--tz", "UTC", "-e", "0 0 * * *", "-e", "@daily", "-e", "0 12 * * *"},
+			stdout: []string{"2 entries fire together 2 times, first at Sat 2026-10-17 00:00:00 UTC:\n  \"0 0 * * *\": 0 0 * * *\n  \"@daily\": @daily\n", "peak: 2 entries"}},
+		{args: []string{"overlaps", "--from", "2026-01-01T00:00:00Z", "--until", "2026-01-01T00:30:00Z", "--tz", "UTC", "-e", "0 0 * * *", "-e", "@daily", "-e", "@midnight",
+			"-e", "0 0 1 1 *"}, stdout: []string{"4 entries fire together 1 time, first at Thu 2026-01-01 00:00:00 UTC:\n", "peak: 4 entries"}},
+		{args: []string{"overlaps", "--max", "1", "--from", "2026-10-19T00:00:00Z", "--tz", "UTC", "-e", "30 8 * * *", tab}, status: ExitInvalid,
+			stdout: []string{"  \"30 8 * * *\": 30 8 * * *\n  " + tab + ":4: 30 8 * * 1-5 /bin/report\n"}},
+		{args: []string{"overlaps", "--from", "2026-10-17T00:00:00Z", "--tz", "UTC", tab}, stdout: []string{"no entries fire together", "peak: 1 entries"}},
+		{args: []string{"validate", "-"}, stdin: "0 9 * * * /bin/true\n", stdout: []string{"-:1: ok"}},
+		{args: []string{"validate", "-"}, stdin: "CRON_TZ=Nowhere/Bogus\n0 9 * * * /bin/true\n", status: ExitInvalid,
+			stdout: []string{`-:2: error: line 1: unknown time zone "Nowhere/Bogus" in CRON_TZ`}},
+		{args: []string{"validate", "-e", "*/5 * * * *", "-e", "@hourly"}, stdout: []string{`"*/5 * * * *": ok`, `"@hourly": ok`}},
+		{args: []string{"validate", "-e", "0 24 * * *", tab, "-"}, stdin: "0 9 * * * /bin/true\n", status: ExitInvalid,
+			stdout: []string{`"0 24 * * *": error`, tab + ":4: ok", "-:1: ok"}},
+		{args: []string{"validate", tab, filepath.Join(c.dir, "missing")}, status: ExitInvalid, stdout: []string{tab + ":3: ok"}, stderr: []string{"missing: stat"}},
+		{args: []string{"validate", "-", "-"}, status: ExitInvalid, stderr: []string{"standard input can only be read once"}},
+		{args: []string{"validate", "-"}, stdin: long, status: ExitInvalid, stderr: []string{"-: bufio.Scanner: token too long"}},
+		{args: []string{"next", "-"}, stdin: long, status: ExitInvalid, stderr: []string{"token too long"}},
+		{args: []string{"explain", "-"}, stdin: long, status: ExitInvalid, stderr: []string{"token too long"}},
+		{args: []string{"convert", "--to", "quartz", "-"}, stdin: long, status: ExitInvalid, stderr: []string{"token too long"}},
+		{args: []string{"fmt", "-e", "0 9 * * *", tab}, stdout: []string{"==> 0 9 * * * <==\n0 9 * * *\n==> " + tab + " <==\n"}},
+		{args: []string{"help"}, stdout: []string{"Commands:"}},
+		{args: []string{"help", "next"}, stderr: []string{"Usage: crontable next [flags] [file|-|expression...]", "-count"}},
+		{status: ExitUsage, stderr: []string{"Usage:"}},
+		{args: []string{"bogus"}, status: ExitUsage, stderr: []string{`unknown command "bogus"`}},
+		{args: []string{"validate", "--output", "xml", "0 9 * * *"}, status: ExitUsage, stderr: []string{`unknown output format "xml"`}},
+		{args: []string{"validate", "--dialect", "fcron", "0 9 * * *"}, status: ExitUsage, stderr: []string{`unknown dialect "fcron"`}},
+		{args: []string{"explain", "--lang", "xx", "0 9 * * *"}, status: ExitUsage, stderr: []string{`unknown language "xx"`}},
+		{args: []string{"next", "--count", "0", "0 9 * * *"}, status: ExitUsage},
+		{args: []string{"diff", "0 9 * * *"}, status: ExitUsage},
+		{args: []string{"diff", "-e", "0 9 * * *", "0 9 * * *", "0 10 * * *"}, status: ExitUsage, stderr: []string{"expected an old and a new file or expression"}},
+		{args: []string{"diff", "--from", "2026-10-17T00:00:00Z", "--tz", "UTC", "-e", "*/15 * * * *", "-e", "0,15,30,45 * * * *"},
+			stdout: []string{"the schedules are equivalent"}},
+		{args: []string{"diff", "--from", "2026-10-17T00:00:00Z", "--until", "2026-10-18T00:00:00Z", "--tz", "UTC", "-e", "0 9 * * *", "0 10 * * *"},
+			status: ExitInvalid, stdout: []string{"- Sat 2026-10-17 09:00:00 UTC\n+ Sat 2026-10-17 10:00:00 UTC\n"}},
+		{args: []string{"overlaps", "--max", "-1", "0 9 * * *"}, status: ExitUsage},
+		{args: []string{"fmt", "--names", "--numbers", "0 9 * * *"}, status: ExitUsage},
+		{args: []string{"fmt", "--write", "0 9 * * *"}, status: ExitUsage, stderr: []string{"--write can only rewrite files"}},
+		{args: []string{"fmt", "--check", "--write", tab}, status: ExitUsage, stderr: []string{"--check and --write cannot be used together"}},
+	}
+	for _, r := range runs {
+		status, stdout, stderr := c.execute(r.stdin, r.args...)
+		c.Assert().Equal(r.status, status, "%v\n%s%s", r.args, stdout, stderr)
+		for _, s := range r.stdout {
+			c.Assert().Contains(stdout, s, r.args)
+		}
+		for _, s := range r.stderr {
+			c.Assert().Contains(stderr, s, r.args)
+		}
+	}
+}
+
+// TestFlagErrors tests that a flag that cannot be parsed is reported once, along with the usage of the command
+func (c *Commands) TestFlagErrors() {
+	status, _, stderr := c.execute("", "validate", "--nope")
+	c.Assert().Equal(ExitUsage, status)
+	c.Assert().Equal(1, strings.Count(stderr, "flag provided but not defined: -nope"), stderr)
+	c.Assert().Contains(stderr, "Usage: crontable validate")
+}
+
+// TestFmtWrite tests that fmt --write rewrites files in place, after which --check finds nothing left to format
+func (c *Commands) TestFmtWrite() {
+	tab := filepath.Join(c.dir, "crontab")
+	status, stdout, _ := c.execute("", "fmt", "--write", tab)
+	c.Assert().Equal(ExitValid, status)
+	c.Assert().Empty(stdout)
+	text, err := os.ReadFile(tab)
+	c.Require().NoError(err)
+	c.Assert().Equal("SHELL=/bin/sh\n# backup\n0  2 * * *   /bin/backup\n30 8 * * 1-5 /bin/report\n", string(text))
+
+	status, stdout, _ = c.execute("", "fmt", "--check", tab)
+	c.Assert().Equal(ExitValid, status)
+	c.Assert().Empty(stdout)
+}
+
+// TestDiffCutOff tests that diff compares both inputs up to the same time when one of them fires too often to list every run time of the window
+func (c *Commands) TestDiffCutOff() {
+	status, stdout, stderr := c.execute("", "diff", "--from", "2026-01-01T00:00:00Z", "--until", "2026-04-30T00:00:00Z", "--tz", "UTC",
+		"* * * * *", "0 * * * *")
+	c.Assert().Equal(ExitInvalid, status)
+	c.Assert().NotContains(stdout, "+ ")
+	c.Assert().Contains(stdout, "- Wed 2026-03-11 10:40:00 UTC\nthe old schedule fires at every time the new one does\n")
+	c.Assert().Contains(stderr, "run times compared up to Wed 2026-03-11 10:40:00 UTC only")
+}
+
+// TestOutputFormats tests that results are written as JSON and YAML when asked
+func (c *Commands) TestOutputFormats() {
+	status, stdout, _ := c.execute("", "validate", "--output", "json", "0 24 * * *")
+	c.Assert().Equal(ExitInvalid, status)
+	var doc struct {
+		Version int
+		Inputs  []struct {
+			Input   string
+			Entries []map[string]interface{}
+		}
+	}
+	c.Require().NoError(json.Unmarshal([]byte(stdout), &doc))
+	c.Assert().Equal(reader.SchemaVersion, doc.Version)
+	c.Require().Len(doc.Inputs, 1)
+	c.Assert().Equal("0 24 * * *", doc.Inputs[0].Input)
+	c.Require().Len(doc.Inputs[0].Entries, 1)
+	c.Assert().Equal(false, doc.Inputs[0].Entries[0]["valid"])
+
+	status, stdout, _ = c.execute("", "next", "--output", "yaml", "--from", "2026-10-17T10:00:00Z", "--tz", "UTC", "--count", "1", "@daily")
+	c.Assert().Equal(ExitValid, status)
+	c.Assert().Equal("version: 1\ninputs:\n  - input: '@daily'\n    entries:\n      - schedule: '@daily'\n        times:\n          - 2026-10-18T00:00:00Z\n", stdout)
+
+	status, stdout, _ = c.execute("", "explain", "--output", "json", "--next", "2", "--tz", "UTC", "-e", "0 9 * * *")
+	c.Assert().Equal(ExitValid, status)
+	c.Require().NoError(json.Unmarshal([]byte(stdout), &doc))
+	entry := doc.Inputs[0].Entries[0]
+	c.Assert().Equal("0 9 * * *", entry["expression"])
+	c.Assert().Len(entry["next"], 2)
+	schedule := entry["schedule"].(map[string]interface{})
+	c.Assert().Equal(float64(reader.SchemaVersion), schedule["version"])
+	hour := schedule["fields"].(map[string]interface{})["hour"].(map[string]interface{})
+	c.Assert().Equal([]interface{}{float64(9)}, hour["values"])
+}
+
+func TestCommands(t *testing.T) {
+	suite.Run(t, new(Commands))
+}
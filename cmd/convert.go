@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"strings"
+)
+
+// converted is the outcome of converting one schedule, as written by the convert command
+type converted struct {
+	Line      int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Schedule  string `json:"schedule" yaml:"schedule"`
+	Converted string `json:"converted,omitempty" yaml:"converted,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	entry *reader.Entry
+}
+
+// runConvert implements the convert command, rewriting every schedule read in the dialect of --dialect as a schedule of the dialect of --to
+func runConvert(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("convert"))
+	toName := fs.String("to", "", "cron implementation to rewrite schedules for: vixie, cronie, quartz, aws or kubernetes")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	if *toName == "" {
+		return usagef("--to is required")
+	}
+	to, err := reader.LookupDialect(*toName)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	ins, ok := e.inputs(fs, o)
+	invalid := !ok
+	reports := make([]report, 0, len(ins))
+	all := make([][]converted, 0, len(ins))
+	for _, in := range ins {
+		results, bad := e.convert(in, o, to)
+		invalid = invalid || bad
+		reports = append(reports, report{Input: in.Name, Entries: results})
+		all = append(all, results)
+	}
+
+	err = e.write(o, documentOf(reports), func(w io.Writer) error {
+		for i, in := range ins {
+			header(w, ins, in)
+			for _, result := range all[i] {
+				if result.Error != "" {
+					continue
+				}
+				if !to.Crontab || in.Inline {
+					fmt.Fprintln(w, result.Converted)
+					continue
+				}
+				entry := *result.entry
+				entry.Schedule = reader.CronRead(result.Converted)
+				fmt.Fprintln(w, entryText(&entry))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid {
+		return errInvalid
+	}
+	return nil
+}
+
+// convert converts every entry of the input for the dialect to, reporting those that cannot be converted along with whether there were any
+func (e *env) convert(in *input, o *options, to *reader.Dialect) ([]converted, bool) {
+	invalid := e.reportUnread(in)
+	results := []converted{}
+	for i := range in.Tab.Entries {
+		entry := &in.Tab.Entries[i]
+		result := converted{Line: entry.Line, Schedule: entry.Schedule.String(), entry: entry}
+		if in.Inline {
+			result.Line = 0
+		}
+		sched, err := o.dialect.Parse(entry.Schedule.String())
+		if err == nil {
+			result.Converted, err = to.Convert(sched)
+		}
+		if err != nil {
+			result.Error = strings.ReplaceAll(err.Error(), "\n", "; ")
+			fmt.Fprintf(e.stderr, "%s: %s\n", location(in, entry), result.Error)
+			invalid = true
+		}
+		results = append(results, result)
+	}
+	return results, invalid
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"sort"
+	"time"
+)
+
+// maxDiffTimes bounds how many run times of each entry the diff command compares, so that schedules firing every second stay tractable over long
+// windows. Run times are then compared only up to the last one of the entry cut off first, on both sides alike.
+const maxDiffTimes = 100000
+
+// diffed is the outcome of comparing the run times of two inputs, as written by the diff command
+type diffed struct {
+	Version int         `json:"version" yaml:"version"`
+	From    time.Time   `json:"from" yaml:"from"`
+	Until   time.Time   `json:"until" yaml:"until"`
+	Removed []time.Time `json:"removed" yaml:"removed"`
+	Added   []time.Time `json:"added" yaml:"added"`
+	// Relation is how the old schedule relates to the new one at all times, when each input holds a single schedule: one of the relations below
+	Relation string `json:"relation,omitempty" yaml:"relation,omitempty"`
+}
+
+// How the old schedule of the diff command relates to the new one, by reader.Equivalent and reader.Subsumes
+const (
+	RelationEquivalent     = "equivalent"
+	RelationOldSubsumesNew = "oldSubsumesNew"
+	RelationNewSubsumesOld = "newSubsumesOld"
+	RelationDifferent      = "different"
+)
+
+// relationTexts words each relation in text output
+var relationTexts = map[string]string{
+	RelationEquivalent:     "the schedules are equivalent",
+	RelationOldSubsumesNew: "the old schedule fires at every time the new one does",
+	RelationNewSubsumesOld: "the new schedule fires at every time the old one does",
+	RelationDifferent:      "the schedules differ",
+}
+
+// runDiff implements the diff command, listing the run times only the old input has as removed, and those only the new input has as added.
+// When each input holds a single schedule, it also tells how they relate at all times, beyond the window compared. Like diff(1), it fails
+// when there are any differences. The old and new inputs are the two given with -e and as arguments together, in that order.
+func runDiff(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("diff"))
+	fromStr := fs.String("from", "", "compare run times after this RFC 3339 time instead of now")
+	untilStr := fs.String("until", "", "compare run times up to this RFC 3339 time instead of a week after --from")
+	tz := fs.String("tz", "Local", "time zone to work out run times in, for schedules without a CRON_TZ line")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	if n := len(o.exprs) + fs.NArg(); n != 2 {
+		return usagef("expected an old and a new file or expression, given with -e or as arguments, got %d", n)
+	}
+	loc, from, until, err := window(*tz, *fromStr, *untilStr)
+	if err != nil {
+		return err
+	}
+	if until.IsZero() {
+		until = from.AddDate(0, 0, 7)
+	}
+
+	var sets [2]map[int64]time.Time
+	var scheds [2][]*reader.Schedule
+	invalid := false
+	cutoff, cutBy := until, ""
+	for i := 0; i < 2; i++ {
+		// the expressions of -e come first, as for every other command
+		var in *input
+		if i < len(o.exprs) {
+			in = inline(o.exprs[i], o.dialect)
+		} else if in, err = e.load(fs.Arg(i-len(o.exprs)), o); err != nil {
+			return err
+		}
+		list, bad := e.upcomingOf(in, loc)
+		invalid = invalid || bad
+		sets[i] = map[int64]time.Time{}
+		for _, u := range list {
+			scheds[i] = append(scheds[i], u.sched)
+			times := runTimes(u.sched, from, until, maxDiffTimes+1)
+			if len(times) > maxDiffTimes {
+				times = times[:maxDiffTimes]
+				if last := times[len(times)-1]; last.Before(cutoff) {
+					cutoff, cutBy = last, u.Schedule
+				}
+			}
+			for _, t := range times {
+				sets[i][t.UnixNano()] = t
+			}
+		}
+	}
+	if invalid {
+		return errInvalid
+	}
+	if cutBy != "" {
+		// compare both sides over the same window, so that the times cut off one side do not show as differences
+		for _, set := range sets {
+			for k, t := range set {
+				if t.After(cutoff) {
+					delete(set, k)
+				}
+			}
+		}
+		fmt.Fprintf(e.stderr, "run times compared up to %s only, as %q fires more than %d times before --until\n", cutoff.Format(timeLayout), cutBy, maxDiffTimes)
+		until = cutoff
+	}
+
+	result := diffed{Version: reader.SchemaVersion, From: from, Until: until, Removed: missing(sets[0], sets[1]), Added: missing(sets[1], sets[0])}
+	if len(scheds[0]) == 1 && len(scheds[1]) == 1 {
+		result.Relation = relation(scheds[0][0], scheds[1][0])
+	}
+	err = e.write(o, result, func(w io.Writer) error {
+		changes := make([]time.Time, 0, len(result.Removed)+len(result.Added))
+		changes = append(append(changes, result.Removed...), result.Added...)
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Before(changes[j]) })
+		for _, t := range changes {
+			mark := "+"
+			if _, ok := sets[0][t.UnixNano()]; ok {
+				mark = "-"
+			}
+			fmt.Fprintf(w, "%s %s\n", mark, t.Format(timeLayout))
+		}
+		if result.Relation != "" {
+			fmt.Fprintln(w, relationTexts[result.Relation])
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Removed)+len(result.Added) > 0 || (result.Relation != "" && result.Relation != RelationEquivalent) {
+		return errInvalid
+	}
+	return nil
+}
+
+// relation tells how the old schedule relates to the new one at all times
+func relation(older, newer *reader.Schedule) string {
+	oldSubsumes, newSubsumes := reader.Subsumes(older, newer), reader.Subsumes(newer, older)
+	switch {
+	case oldSubsumes && newSubsumes:
+		return RelationEquivalent
+	case oldSubsumes:
+		return RelationOldSubsumesNew
+	case newSubsumes:
+		return RelationNewSubsumesOld
+	}
+	return RelationDifferent
+}
+
+// missing returns the times of a that b lacks, in order
+func missing(a, b map[int64]time.Time) []time.Time {
+	times := []time.Time{}
+	for k, t := range a {
+		if _, ok := b[k]; !ok {
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
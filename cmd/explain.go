@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/meaning"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// explained is the explanation of one schedule, as written by the explain command
+type explained struct {
+	Line        int                 `json:"line,omitempty" yaml:"line,omitempty"`
+	Expression  string              `json:"expression" yaml:"expression"`
+	User        string              `json:"user,omitempty" yaml:"user,omitempty"`
+	Command     string              `json:"command,omitempty" yaml:"command,omitempty"`
+	Schedule    *reader.Schedule    `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	Explanation string              `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+	Diagnostics []reader.Diagnostic `json:"diagnostics,omitempty" yaml:"diagnostics,omitempty"`
+	Next        []time.Time         `json:"next,omitempty" yaml:"next,omitempty"`
+}
+
+// runExplain implements the explain command, describing when each schedule of every input runs
+func runExplain(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("explain"))
+	next := fs.Int("next", 0, "number of upcoming run times to list for each schedule")
+	tz := fs.String("tz", "Local", "time zone to work out run times in, for schedules without a CRON_TZ line")
+	lang := fs.String("lang", meaning.English.Tag, "language to explain schedules in: en, fr, de, es or pt")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	l, err := meaning.LookupLocale(*lang)
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	_, from, _, err := window(*tz, "", "")
+	if err != nil {
+		return err
+	}
+	if *next < 0 {
+		return usagef("--next must not be negative")
+	}
+
+	ins, ok := e.inputs(fs, o)
+	invalid := !ok
+	reports := make([]report, 0, len(ins))
+	all := make([][]explained, 0, len(ins))
+	for _, in := range ins {
+		results, bad := e.explain(in, o, l)
+		for i := range results {
+			if *next > 0 && results[i].Schedule != nil {
+				// from is in loc, which schedules without a CRON_TZ line fire in
+				results[i].Next = runTimes(results[i].Schedule, from, time.Time{}, *next)
+			}
+		}
+		invalid = invalid || bad
+		reports = append(reports, report{Input: in.Name, Entries: results})
+		all = append(all, results)
+	}
+
+	err = e.write(o, documentOf(reports), func(w io.Writer) error {
+		for i, in := range ins {
+			header(w, ins, in)
+			for j, result := range all[i] {
+				if result.Schedule == nil {
+					continue
+				}
+				if in.Inline {
+					fmt.Fprintf(w, "expression: %s\n", result.Expression)
+				} else {
+					fmt.Fprintf(w, "entry on line %d: %s\n", result.Line, entryText(&in.Tab.Entries[j]))
+				}
+				if err := writeFields(w, result.Schedule); err != nil {
+					return err
+				}
+				fmt.Fprintln(w, result.Explanation)
+				for _, t := range result.Next {
+					fmt.Fprintf(w, "  next: %s\n", t.Format(timeLayout))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid {
+		return errInvalid
+	}
+	return nil
+}
+
+// explain explains every entry of the input in the locale l, reporting its diagnostics along with whether any was an error
+func (e *env) explain(in *input, o *options, l *meaning.Locale) ([]explained, bool) {
+	invalid := e.reportUnread(in)
+	results := []explained{}
+	for i := range in.Tab.Entries {
+		entry := &in.Tab.Entries[i]
+		result := explained{Line: entry.Line, Expression: entry.Schedule.String(), User: entry.User, Command: entry.Command}
+		if in.Inline {
+			result.Line = 0
+		}
+		for _, diag := range o.dialect.Diagnose(entry.Schedule.String()) {
+			result.Diagnostics = append(result.Diagnostics, diag)
+			if o.output == OutputText {
+				fmt.Fprintf(e.stderr, "%s: %s\n", location(in, entry), diag.Render(entry.Schedule.String()))
+			}
+			if diag.Severity == reader.SeverityError {
+				invalid = true
+			}
+		}
+		sched, err := in.Tab.Schedule(*entry)
+		switch {
+		case err == nil:
+			result.Schedule = sched
+			result.Explanation = string(l.ExplainSchedule(sched))
+		case len(result.Diagnostics) == 0:
+			// the schedule is sound, but its CRON_TZ line is not
+			fmt.Fprintf(e.stderr, "%s: %s\n", location(in, entry), err.Error())
+			invalid = true
+		}
+		results = append(results, result)
+	}
+	return results, invalid
+}
+
+// writeFields writes a line for each field of the schedule: its name, its token as written, and the values it expands to
+func writeFields(w io.Writer, sched *reader.Schedule) error {
+	if sched.Trigger == reader.TriggerReboot {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fields := []struct {
+		name string
+		c    *reader.Catcher
+	}{
+		{reader.Second, sched.Second},
+		{reader.Minute, &sched.Minute},
+		{reader.Hour, &sched.Hour},
+		{reader.DayOfTheMonth, &sched.DayOfMonth},
+		{reader.Month, &sched.Month},
+		{reader.DayOfTheWeek, &sched.DayOfWeek},
+		{reader.Year, sched.Year},
+	}
+	for _, f := range fields {
+		if f.c == nil {
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", f.name, f.c.Raw, valueList(f.c))
+	}
+	return tw.Flush()
+}
+
+// valueList writes the values a field expands to as a list, joining runs of three or more consecutive values into a range. Quartz special terms,
+// whose days depend on the calendar, are written as they are, and the "?" placeholder as "any".
+func valueList(c *reader.Catcher) string {
+	if c.DelimKind == reader.DelimAny {
+		return "any"
+	}
+	var specials []reader.Span
+	for _, span := range c.Spans {
+		switch span.Kind {
+		case reader.DelimLast, reader.DelimWeekday, reader.DelimLastWeekday, reader.DelimNth:
+			specials = append(specials, span)
+		}
+	}
+	return reader.FormatValues(c.Values(), specials...)
+}
+
+// reportUnread reports the lines of a crontab that could not be read as entries, reporting whether there were any
+func (e *env) reportUnread(in *input) bool {
+	if in.Err == nil {
+		return false
+	}
+	fmt.Fprintf(e.stderr, "%s: %s\n", in.Name, in.Err.Error())
+	return true
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"os"
+)
+
+// formatted is the outcome of formatting one input, as written by the fmt command
+type formatted struct {
+	Name      string `json:"name" yaml:"name"`
+	Formatted string `json:"formatted" yaml:"formatted"`
+	// Changed reports whether formatting changed the input
+	Changed bool `json:"changed" yaml:"changed"`
+}
+
+// runFmt implements the fmt command, writing every input back with its schedules in a consistent style and its entries aligned in columns.
+// With --check it lists the inputs that are not formatted instead, and with --write it rewrites the files that are not. Entries whose schedules are
+// not valid are left as written and reported, failing the command.
+func runFmt(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("fmt"))
+	check := fs.Bool("check", false, "list the inputs that are not formatted instead of writing them, exiting 1 when there are any")
+	write := fs.Bool("write", false, "rewrite files that are not formatted in place instead of writing them to standard output")
+	compress := fs.Bool("compress", false, "rewrite lists as the shortest equivalent of ranges and steps, such as */15 for 0,15,30,45")
+	names := fs.Bool("names", false, "write months and days of the week by name, such as MON-FRI")
+	numbers := fs.Bool("numbers", false, "write months and days of the week as numbers, such as 1-5")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	style := reader.Style{Compress: *compress}
+	switch {
+	case *names && *numbers:
+		return usagef("--names and --numbers cannot be used together")
+	case *names:
+		style.Names = reader.NamesSpelled
+	case *numbers:
+		style.Names = reader.NamesNumbered
+	}
+	if *check && *write {
+		return usagef("--check and --write cannot be used together")
+	}
+	ins, ok := e.inputs(fs, o)
+	invalid := !ok
+	results := make([]formatted, 0, len(ins))
+	for _, in := range ins {
+		if *write && (in.Inline || in.Name == "-") {
+			return usagef("--write can only rewrite files, not %q", in.Name)
+		}
+		invalid = e.reportUnread(in) || invalid
+		invalid = e.reportInvalid(in) || invalid
+		result, err := format(in, style)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	unformatted := false
+	for i, in := range ins {
+		if !results[i].Changed {
+			continue
+		}
+		unformatted = true
+		if *write {
+			if err := rewrite(in.Name, results[i].Formatted); err != nil {
+				return err
+			}
+		}
+	}
+	err := e.write(o, documentOf(results), func(w io.Writer) error {
+		for i, in := range ins {
+			switch {
+			case *check:
+				if results[i].Changed {
+					fmt.Fprintln(w, in.Name)
+				}
+				continue
+			case *write:
+				continue
+			}
+			header(w, ins, in)
+			if _, err := fmt.Fprint(w, results[i].Formatted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid || (*check && unformatted) {
+		return errInvalid
+	}
+	return nil
+}
+
+// format writes the input back in the style passed in: an expression alone, or a crontab laid out by reader.FormatCrontab.
+// Expressions that cannot be read are kept as they are.
+func format(in *input, style reader.Style) (formatted, error) {
+	if in.Inline {
+		text, err := in.Tab.Dialect.FormatExpression(in.Name, style)
+		if err != nil {
+			text = in.Name
+		}
+		return formatted{Name: in.Name, Formatted: text + "\n", Changed: text != in.Name}, nil
+	}
+	text, err := reader.FormatCrontab(bytes.NewReader(in.Text), in.Tab.Kind, in.Tab.Dialect, style)
+	if err != nil {
+		return formatted{}, err
+	}
+	return formatted{Name: in.Name, Formatted: text, Changed: text != string(in.Text)}, nil
+}
+
+// reportInvalid reports the errors in the schedules of the input on standard error, as fmt leaves those entries as written, reporting whether
+// there were any
+func (e *env) reportInvalid(in *input) bool {
+	invalid := false
+	for i := range in.Tab.Entries {
+		entry := &in.Tab.Entries[i]
+		for _, diag := range in.Tab.Dialect.Diagnose(entry.Schedule.String()) {
+			if diag.Severity == reader.SeverityError {
+				fmt.Fprintf(e.stderr, "%s: %s\n", location(in, entry), diag.Render(entry.Schedule.String()))
+				invalid = true
+			}
+		}
+	}
+	return invalid
+}
+
+// rewrite replaces the content of the file at path, keeping its permissions
+func rewrite(path, text string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), info.Mode().Perm())
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// timeLayout is how run times are printed in table output
+const timeLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// upcoming holds the next run times of one schedule, either an expression passed in or an entry of a crontab file
+type upcoming struct {
+	Line     int         `json:"line,omitempty" yaml:"line,omitempty"`
+	Schedule string      `json:"schedule" yaml:"schedule"`
+	Command  string      `json:"command,omitempty" yaml:"command,omitempty"`
+	Reboot   bool        `json:"reboot,omitempty" yaml:"reboot,omitempty"`
+	Times    []time.Time `json:"times" yaml:"times"`
+}
+
+// runNext implements the next command, listing the upcoming run times of an expression or of every entry of a crontab
+func runNext(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("next"))
+	fromStr := fs.String("from", "", "list run times after this RFC 3339 time instead of now")
+	untilStr := fs.String("until", "", "list run times up to this RFC 3339 time; lifts the default count")
+	count := fs.Int("count", 10, "number of run times to list for each schedule; 0 lists every one up to --until")
+	tz := fs.String("tz", "Local", "time zone to work out run times in, for schedules without a CRON_TZ line")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	loc, from, until, err := window(*tz, *fromStr, *untilStr)
+	if err != nil {
+		return err
+	}
+	if !until.IsZero() && !isFlagSet(fs, "count") {
+		*count = 0
+	}
+	if *count <= 0 && until.IsZero() {
+		return usagef("--count must be positive unless --until is given")
+	}
+
+	ins, ok := e.inputs(fs, o)
+	invalid := !ok
+	reports := make([]report, 0, len(ins))
+	all := make([][]upcomingSchedule, 0, len(ins))
+	for _, in := range ins {
+		list, bad := e.upcomingOf(in, loc)
+		invalid = invalid || bad
+		out := make([]upcoming, 0, len(list))
+		for i := range list {
+			list[i].Times = runTimes(list[i].sched, from, until, *count)
+			out = append(out, list[i].upcoming)
+		}
+		reports = append(reports, report{Input: in.Name, Entries: out})
+		all = append(all, list)
+	}
+	err = e.write(o, documentOf(reports), func(w io.Writer) error {
+		for i, in := range ins {
+			header(w, ins, in)
+			if err := writeUpcomingTable(w, all[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid {
+		return errInvalid
+	}
+	return nil
+}
+
+// window reads the time zone and the RFC 3339 times bounding the run times a command lists. The start defaults to now, and the end is left zero when not given.
+func window(tz, fromStr, untilStr string) (*time.Location, time.Time, time.Time, error) {
+	var from, until time.Time
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, from, until, usagef("unknown time zone %q", tz)
+	}
+	from = time.Now().In(loc)
+	if fromStr != "" {
+		if from, err = time.ParseInLocation(time.RFC3339, fromStr, loc); err != nil {
+			return nil, from, until, usagef("invalid --from time: %s", err.Error())
+		}
+	}
+	if untilStr != "" {
+		if until, err = time.ParseInLocation(time.RFC3339, untilStr, loc); err != nil {
+			return nil, from, until, usagef("invalid --until time: %s", err.Error())
+		}
+	}
+	return loc, from, until, nil
+}
+
+// upcomingOf parses the schedule of every entry of the input, reporting those that cannot be parsed along with whether there were any.
+// Schedules without a CRON_TZ line work out their run times in loc.
+func (e *env) upcomingOf(in *input, loc *time.Location) ([]upcomingSchedule, bool) {
+	invalid := e.reportUnread(in)
+	var list []upcomingSchedule
+	for i := range in.Tab.Entries {
+		entry := &in.Tab.Entries[i]
+		sched, err := in.Tab.Schedule(*entry)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "%s: %s\n", location(in, entry), err.Error())
+			invalid = true
+			continue
+		}
+		if sched.Location == nil {
+			sched.Location = loc
+		}
+		u := upcomingSchedule{
+			upcoming: upcoming{Line: entry.Line, Schedule: sched.Expr, Command: entry.Command, Reboot: sched.Trigger == reader.TriggerReboot},
+			sched:    sched,
+		}
+		if in.Inline {
+			u.Line = 0
+		}
+		list = append(list, u)
+	}
+	return list, invalid
+}
+
+// upcomingSchedule pairs the run times listed for a schedule with the schedule they are worked out from
+type upcomingSchedule struct {
+	upcoming
+	sched *reader.Schedule
+}
+
+// runTimes lists the run times of the schedule after from, stopping after count of them when count is positive, and at until when it is set
+func runTimes(sched *reader.Schedule, from, until time.Time, count int) []time.Time {
+	times := []time.Time{}
+	it := sched.Iterate(from)
+	for count <= 0 || len(times) < count {
+		t := it.Next()
+		if t.IsZero() || (!until.IsZero() && t.After(until)) {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// writeUpcomingTable writes the run times of every schedule as a table, one row per run time
+func writeUpcomingTable(w io.Writer, list []upcomingSchedule) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSCHEDULE\tRUN TIME\tCOMMAND")
+	for _, u := range list {
+		line := "-"
+		if u.Line > 0 {
+			line = strconv.Itoa(u.Line)
+		}
+		switch {
+		case u.Reboot:
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", line, u.Schedule, "at system startup", u.Command)
+		case len(u.Times) == 0:
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", line, u.Schedule, "none", u.Command)
+		}
+		for _, t := range u.Times {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", line, u.Schedule, t.Format(timeLayout), u.Command)
+		}
+	}
+	return tw.Flush()
+}
+
+// isFlagSet reports whether the named flag was passed on the command line rather than left at its default
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+	"strings"
+	"time"
+)
+
+// overlapEntry names an entry firing together with others, as written by the overlaps command
+type overlapEntry struct {
+	Input    string `json:"input" yaml:"input"`
+	Line     int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Schedule string `json:"schedule" yaml:"schedule"`
+	Command  string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// overlapGroup is a set of entries firing together, along with every minute they do
+type overlapGroup struct {
+	Concurrency int            `json:"concurrency" yaml:"concurrency"`
+	Entries     []overlapEntry `json:"entries" yaml:"entries"`
+	Times       []time.Time    `json:"times" yaml:"times"`
+}
+
+// overlapMinute is a minute in which two or more entries fire, along with how many do
+type overlapMinute struct {
+	At          time.Time `json:"at" yaml:"at"`
+	Concurrency int       `json:"concurrency" yaml:"concurrency"`
+}
+
+// overlapped is the outcome of looking for entries firing together, as written by the overlaps command
+type overlapped struct {
+	Version int             `json:"version" yaml:"version"`
+	From    time.Time       `json:"from" yaml:"from"`
+	Until   time.Time       `json:"until" yaml:"until"`
+	Peak    int             `json:"peak" yaml:"peak"`
+	PeakAt  *time.Time      `json:"peakAt,omitempty" yaml:"peakAt,omitempty"`
+	Groups  []overlapGroup  `json:"groups" yaml:"groups"`
+	Minutes []overlapMinute `json:"minutes" yaml:"minutes"`
+}
+
+// runOverlaps implements the overlaps command, listing the sets of entries firing within the same minute across every input, and the most entries
+// firing within a single minute. With --max it fails when more entries than that ever fire together.
+func runOverlaps(e *env, args []string) error {
+	fs, o := e.flagSet(lookup("overlaps"))
+	fromStr := fs.String("from", "", "look for overlaps from this RFC 3339 time instead of now")
+	untilStr := fs.String("until", "", "look for overlaps up to this RFC 3339 time instead of a day after --from")
+	tz := fs.String("tz", "Local", "time zone to work out run times in, for schedules without a CRON_TZ line")
+	limit := fs.Int("max", 0, "exit 1 when more entries than this fire within the same minute; 0 never does")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return usagef("--max must not be negative")
+	}
+	loc, from, until, err := window(*tz, *fromStr, *untilStr)
+	if err != nil {
+		return err
+	}
+	if until.IsZero() {
+		until = from.AddDate(0, 0, 1)
+	}
+
+	ins, ok := e.inputs(fs, o)
+	invalid := !ok
+	var scheds []*reader.Schedule
+	var entries []overlapEntry
+	for _, in := range ins {
+		list, bad := e.upcomingOf(in, loc)
+		invalid = invalid || bad
+		for _, u := range list {
+			scheds = append(scheds, u.sched)
+			entries = append(entries, overlapEntry{Input: in.Name, Line: u.Line, Schedule: u.Schedule, Command: u.Command})
+		}
+	}
+
+	report := reader.Overlaps(scheds, from, until)
+	result := overlapped{Version: reader.SchemaVersion, From: from, Until: until, Peak: report.Peak, Groups: []overlapGroup{}, Minutes: []overlapMinute{}}
+	if report.Peak > 0 {
+		result.PeakAt = &report.PeakAt
+	}
+	for _, g := range report.Groups {
+		group := overlapGroup{Concurrency: len(g.Schedules), Times: g.Times}
+		for _, i := range g.Schedules {
+			group.Entries = append(group.Entries, entries[i])
+		}
+		result.Groups = append(result.Groups, group)
+	}
+	for _, collision := range report.Collisions {
+		result.Minutes = append(result.Minutes, overlapMinute{At: collision.At, Concurrency: collision.Concurrency()})
+	}
+
+	err = e.write(o, result, func(w io.Writer) error {
+		if len(result.Groups) == 0 {
+			fmt.Fprintln(w, "no entries fire together")
+		}
+		for _, g := range result.Groups {
+			times := "times"
+			if len(g.Times) == 1 {
+				times = "time"
+			}
+			fmt.Fprintf(w, "%d entries fire together %d %s, first at %s:\n", g.Concurrency, len(g.Times), times, g.Times[0].Format(timeLayout))
+			for _, entry := range g.Entries {
+				fmt.Fprintf(w, "  %s %s\n", entry.name(), strings.TrimSpace(entry.Schedule+" "+entry.Command))
+			}
+		}
+		if result.PeakAt != nil {
+			fmt.Fprintf(w, "peak: %d entries at %s\n", result.Peak, result.PeakAt.Format(timeLayout))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid || (*limit > 0 && report.Peak > *limit) {
+		return errInvalid
+	}
+	return nil
+}
+
+// name names the entry for text output: its input with its line, or the expression alone
+func (oe overlapEntry) name() string {
+	if oe.Line == 0 {
+		return fmt.Sprintf("%q:", oe.Input)
+	}
+	return fmt.Sprintf("%s:%d:", oe.Input, oe.Line)
+}
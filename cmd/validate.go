@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"io"
+)
+
+// checked is the outcome of checking one schedule, as written by the validate and lint commands
+type checked struct {
+	Line        int                 `json:"line,omitempty" yaml:"line,omitempty"`
+	Schedule    string              `json:"schedule" yaml:"schedule"`
+	Valid       bool                `json:"valid" yaml:"valid"`
+	Diagnostics []reader.Diagnostic `json:"diagnostics,omitempty" yaml:"diagnostics,omitempty"`
+	// Error reports a CRON_TZ or TZ line above the entry naming an unknown time zone
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	rendered []string
+}
+
+// runValidate implements the validate command, failing when any schedule has errors
+func runValidate(e *env, args []string) error {
+	return check(e, lookup("validate"), args, reader.SeverityError)
+}
+
+// runLint implements the lint command, failing when any schedule has errors or warnings
+func runLint(e *env, args []string) error {
+	return check(e, lookup("lint"), args, reader.SeverityWarning)
+}
+
+// check diagnoses every schedule of every input read by the command, reporting the diagnostics as severe as severity or more, and failing when there are any
+func check(e *env, c *command, args []string, severity int) error {
+	fs, o := e.flagSet(c)
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	ins, ok := e.inputs(fs, o)
+	failed := !ok
+	reports := make([]report, 0, len(ins))
+	all := make([][]checked, 0, len(ins))
+	for _, in := range ins {
+		results, bad := e.check(in, o, severity)
+		failed = failed || bad
+		reports = append(reports, report{Input: in.Name, Entries: results})
+		all = append(all, results)
+	}
+
+	err := e.write(o, documentOf(reports), func(w io.Writer) error {
+		for i, in := range ins {
+			for j, result := range all[i] {
+				if len(result.rendered) == 0 {
+					fmt.Fprintf(w, "%s: ok\n", location(in, &in.Tab.Entries[j]))
+				}
+				for _, r := range result.rendered {
+					fmt.Fprintf(w, "%s: %s\n", location(in, &in.Tab.Entries[j]), r)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if failed {
+		return errInvalid
+	}
+	return nil
+}
+
+// check diagnoses every entry of the input, keeping the diagnostics as severe as severity or more, and reporting whether there were any
+func (e *env) check(in *input, o *options, severity int) ([]checked, bool) {
+	failed := e.reportUnread(in)
+	results := []checked{}
+	for i := range in.Tab.Entries {
+		entry := &in.Tab.Entries[i]
+		result := checked{Line: entry.Line, Schedule: entry.Schedule.String(), Valid: true}
+		if in.Inline {
+			result.Line = 0
+		}
+		for _, diag := range o.dialect.Diagnose(entry.Schedule.String()) {
+			if diag.Severity == reader.SeverityError {
+				result.Valid = false
+			}
+			// severities count down from errors, so lower is more severe
+			if diag.Severity > severity {
+				continue
+			}
+			failed = true
+			result.Diagnostics = append(result.Diagnostics, diag)
+			result.rendered = append(result.rendered, diag.Render(entry.Schedule.String()))
+		}
+		// the schedule fires in the time zone set for it, which must be known for it to fire at all
+		if _, err := in.Tab.Location(*entry); err != nil {
+			result.Valid, result.Error, failed = false, err.Error(), true
+			result.rendered = append(result.rendered, reader.SeverityName(reader.SeverityError)+": "+err.Error())
+		}
+		results = append(results, result)
+	}
+	return results, failed
+}
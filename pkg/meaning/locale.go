@@ -0,0 +1,194 @@
+package meaning
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownLocale classifies a language tag no Locale is registered for in Locales
+var ErrUnknownLocale = errors.New("unknown language")
+
+// Forms holds the forms of a message that depends on how many values it names: One and Other for a single value, picked by the plural rule of
+// the locale, and List for a list of values
+type Forms struct {
+	One   string
+	Other string
+	List  string
+}
+
+// Catalog holds the messages explanations are put together from, as fmt templates. Times of day are written as 24-hour clock times such as "09:00",
+// and lists are joined with commas and And or Or before their last term.
+type Catalog struct {
+	// At names clock times: "at %s"
+	At string
+	// EverySecond, EveryMinute and EveryHour name schedules firing at every value of a field
+	EverySecond string
+	EveryMinute string
+	EveryHour   string
+	// EverySeconds through EveryYears name steps: "every %d minutes"
+	EverySeconds string
+	EveryMinutes string
+	EveryHours   string
+	EveryDays    string
+	EveryMonths  string
+	EveryYears   string
+	// AtSeconds and AtMinutes name seconds within the minute and minutes within the hour: "at %s minutes past the hour"
+	AtSeconds Forms
+	AtMinutes Forms
+	// BetweenSeconds and BetweenMinutes bound windows of seconds and minutes: "between %s and %s minutes past the hour"
+	BetweenSeconds string
+	BetweenMinutes string
+	// StartingSecond, StartingMinute, StartingHour, StartingDay and StartingMonth name where steps start: "starting at %s minutes past the hour"
+	StartingSecond string
+	StartingMinute string
+	StartingHour   string
+	StartingDay    string
+	StartingMonth  string
+	// Between bounds windows of clock times: "between %s and %s"
+	Between string
+	// BetweenDays and BetweenMonths bound windows of days of the month and of months
+	BetweenDays   string
+	BetweenMonths string
+	// FromThrough names runs of hours at the same minute: "from %s through %s"
+	FromThrough string
+	// DuringHours names lists of hours: "during the %s hours"
+	DuringHours string
+	// FromYear names where steps of years start: "from %d"
+	FromYear string
+	// Through names runs of three or more consecutive values: "%s through %s"; WeekdayThrough names runs of days of the week
+	Through        string
+	WeekdayThrough string
+	// And and Or join the last term of a list
+	And string
+	Or  string
+	// OnDays names days of the month: "on the %s of the month"; OnDaysOf names them within months: "on the %s of %s"
+	OnDays   string
+	OnDaysOf string
+	// OnWeekdays names days of the week: "on %s"
+	OnWeekdays string
+	// AndEvery names days of the week a schedule also fires on, besides its days of the month: "and every %s"
+	AndEvery string
+	// IfWeekday names days of the week the days of the month must fall on: "if it is a %s"
+	IfWeekday string
+	// InMonths and InYears name months and years: "in %s"
+	InMonths string
+	InYears  string
+	// LastDay through NthDayOfWeek name the Quartz special terms of the day fields. LastDayOffset is given the ordinal of the day counted from the end
+	// of the month, NearestWeekday a day of the month, LastDayOfWeek a day of the week, and NthDayOfWeek an ordinal and a day of the week.
+	LastDay        string
+	LastDayOffset  string
+	NearestWeekday string
+	LastWeekday    string
+	LastDayOfWeek  string
+	NthDayOfWeek   string
+	// Macros holds the explanation of each nickname accepted in place of a cron expression
+	Macros map[string]string
+	// Weekdays names the days of the week from Monday, and Months the months from January
+	Weekdays [7]string
+	Months   [12]string
+}
+
+// Locale is a language explanations are written in: a catalog of messages, along with the rules numbers are written by in the language
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale, such as "en"
+	Tag      string
+	Messages Catalog
+	// Ordinal writes a number as an ordinal, such as "3rd" for 3
+	Ordinal func(n int) string
+	// Day writes a day of the month as dates are written, such as "1st" for 1
+	Day func(n int) string
+	// Plural reports whether a single value n takes the Other form of a message rather than its One form
+	Plural func(n int) bool
+}
+
+// English writes explanations in English
+var English = &Locale{
+	Tag: "en",
+	Messages: Catalog{
+		At:             "at %s",
+		EverySecond:    "every second",
+		EveryMinute:    "every minute",
+		EveryHour:      "every hour",
+		EverySeconds:   "every %d seconds",
+		EveryMinutes:   "every %d minutes",
+		EveryHours:     "every %d hours",
+		EveryDays:      "every %d days",
+		EveryMonths:    "every %d months",
+		EveryYears:     "every %d years",
+		AtSeconds:      Forms{One: "at %s second past the minute", Other: "at %s seconds past the minute", List: "at %s seconds past the minute"},
+		AtMinutes:      Forms{One: "at %s minute past the hour", Other: "at %s minutes past the hour", List: "at %s minutes past the hour"},
+		BetweenSeconds: "between %s and %s seconds past the minute",
+		BetweenMinutes: "between %s and %s minutes past the hour",
+		StartingSecond: "starting at %s seconds past the minute",
+		StartingMinute: "starting at %s minutes past the hour",
+		StartingHour:   "starting at %s",
+		StartingDay:    "starting on the %s of the month",
+		StartingMonth:  "starting in %s",
+		Between:        "between %s and %s",
+		BetweenDays:    "between the %s and the %s of the month",
+		BetweenMonths:  "between %s and %s",
+		FromThrough:    "from %s through %s",
+		DuringHours:    "during the %s hours",
+		FromYear:       "from %d",
+		Through:        "%s through %s",
+		WeekdayThrough: "%s through %s",
+		And:            "and",
+		Or:             "or",
+		OnDays:         "on the %s of the month",
+		OnDaysOf:       "on the %s of %s",
+		OnWeekdays:     "on %s",
+		AndEvery:       "and every %s",
+		IfWeekday:      "if it is a %s",
+		InMonths:       "in %s",
+		InYears:        "in %s",
+		LastDay:        TextLastDay,
+		LastDayOffset:  TextLastDayOffset,
+		NearestWeekday: TextNearestWeekday,
+		LastWeekday:    TextLastWeekday,
+		LastDayOfWeek:  TextLastDayOfWeek,
+		NthDayOfWeek:   TextNthDayOfWeek,
+		Macros:         TextMacro,
+		Weekdays:       [7]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
+		Months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	},
+	Ordinal: NorminalToOrdinal,
+	Day:     NorminalToOrdinal,
+	Plural:  func(n int) bool { return n != 1 },
+}
+
+// Locales holds the locales explanations can be written in by language tag. Registering a Locale here makes it available to LookupLocale,
+// and so to the --lang flag of the crontable command.
+var Locales = map[string]*Locale{
+	English.Tag:    English,
+	French.Tag:     French,
+	German.Tag:     German,
+	Spanish.Tag:    Spanish,
+	Portuguese.Tag: Portuguese,
+}
+
+// LookupLocale returns the locale of the language tag passed in, matching case insensitively and falling back from regional tags such as "pt-BR"
+// or "fr_CA.UTF-8" to their language. It errors with ErrUnknownLocale when there is none.
+func LookupLocale(tag string) (*Locale, error) {
+	key := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if i := strings.IndexByte(key, '.'); i >= 0 {
+		key = key[:i]
+	}
+	for {
+		if l, ok := Locales[key]; ok {
+			return l, nil
+		}
+		i := strings.LastIndexByte(key, '-')
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	tags := make([]string, 0, len(Locales))
+	for t := range Locales {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return nil, fmt.Errorf("%w %q, expected one of %s", ErrUnknownLocale, tag, strings.Join(tags, ", "))
+}
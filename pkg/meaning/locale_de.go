@@ -0,0 +1,74 @@
+package meaning
+
+import (
+	"strconv"
+)
+
+// German writes explanations in German
+var German = &Locale{
+	Tag: "de",
+	Messages: Catalog{
+		At:             "um %s",
+		EverySecond:    "jede Sekunde",
+		EveryMinute:    "jede Minute",
+		EveryHour:      "jede Stunde",
+		EverySeconds:   "alle %d Sekunden",
+		EveryMinutes:   "alle %d Minuten",
+		EveryHours:     "alle %d Stunden",
+		EveryDays:      "alle %d Tage",
+		EveryMonths:    "alle %d Monate",
+		EveryYears:     "alle %d Jahre",
+		AtSeconds:      Forms{One: "bei Sekunde %s", Other: "bei Sekunde %s", List: "bei den Sekunden %s"},
+		AtMinutes:      Forms{One: "zur Minute %s jeder Stunde", Other: "zur Minute %s jeder Stunde", List: "zu den Minuten %s jeder Stunde"},
+		BetweenSeconds: "zwischen Sekunde %s und %s",
+		BetweenMinutes: "zwischen Minute %s und %s",
+		StartingSecond: "ab Sekunde %s",
+		StartingMinute: "ab Minute %s",
+		StartingHour:   "ab %s",
+		StartingDay:    "ab dem %s des Monats",
+		StartingMonth:  "ab %s",
+		Between:        "zwischen %s und %s",
+		BetweenDays:    "zwischen dem %s und dem %s des Monats",
+		BetweenMonths:  "zwischen %s und %s",
+		FromThrough:    "von %s bis %s",
+		DuringHours:    "jeweils in der Stunde ab %s",
+		FromYear:       "ab %d",
+		Through:        "%s bis %s",
+		WeekdayThrough: "%s bis %s",
+		And:            "und",
+		Or:             "oder",
+		OnDays:         "am %s des Monats",
+		OnDaysOf:       "am %s %s",
+		OnWeekdays:     "am %s",
+		AndEvery:       "und jeden %s",
+		IfWeekday:      "wenn es ein %s ist",
+		InMonths:       "im %s",
+		InYears:        "im Jahr %s",
+		LastDay:        "am letzten Tag des Monats",
+		LastDayOffset:  "am %s letzten Tag des Monats",
+		NearestWeekday: "am Werktag, der dem %s des Monats am nächsten liegt",
+		LastWeekday:    "am letzten Werktag des Monats",
+		LastDayOfWeek:  "am letzten %s des Monats",
+		NthDayOfWeek:   "am %s %s des Monats",
+		Macros: map[string]string{
+			"@yearly":   "Jedes Jahr um Mitternacht am 1. Januar",
+			"@annually": "Jedes Jahr um Mitternacht am 1. Januar",
+			"@monthly":  "Jeden Monat um Mitternacht am 1.",
+			"@weekly":   "Jede Woche um Mitternacht am Sonntag",
+			"@daily":    "Jeden Tag um Mitternacht",
+			"@midnight": "Jeden Tag um Mitternacht",
+			"@hourly":   "Jede Stunde zu Beginn der Stunde",
+			"@reboot":   "Beim Systemstart",
+		},
+		Weekdays: [7]string{"Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag", "Sonntag"},
+		Months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	},
+	// German writes ordinals and dates alike, with a period after the number, such as "3."
+	Ordinal: germanOrdinal,
+	Day:     germanOrdinal,
+	Plural:  func(n int) bool { return n != 1 },
+}
+
+func germanOrdinal(n int) string {
+	return strconv.Itoa(n) + "."
+}
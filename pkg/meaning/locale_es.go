@@ -0,0 +1,70 @@
+package meaning
+
+import (
+	"strconv"
+)
+
+// Spanish writes explanations in Spanish
+var Spanish = &Locale{
+	Tag: "es",
+	Messages: Catalog{
+		At:             "a las %s",
+		EverySecond:    "cada segundo",
+		EveryMinute:    "cada minuto",
+		EveryHour:      "cada hora",
+		EverySeconds:   "cada %d segundos",
+		EveryMinutes:   "cada %d minutos",
+		EveryHours:     "cada %d horas",
+		EveryDays:      "cada %d días",
+		EveryMonths:    "cada %d meses",
+		EveryYears:     "cada %d años",
+		AtSeconds:      Forms{One: "en el segundo %s", Other: "en el segundo %s", List: "en los segundos %s"},
+		AtMinutes:      Forms{One: "en el minuto %s de cada hora", Other: "en el minuto %s de cada hora", List: "en los minutos %s de cada hora"},
+		BetweenSeconds: "entre los segundos %s y %s",
+		BetweenMinutes: "entre los minutos %s y %s",
+		StartingSecond: "a partir del segundo %s",
+		StartingMinute: "a partir del minuto %s",
+		StartingHour:   "a partir de las %s",
+		StartingDay:    "a partir del día %s del mes",
+		StartingMonth:  "a partir de %s",
+		Between:        "entre las %s y las %s",
+		BetweenDays:    "entre el día %s y el %s del mes",
+		BetweenMonths:  "entre %s y %s",
+		FromThrough:    "de las %s a las %s",
+		DuringHours:    "durante las horas de las %s",
+		FromYear:       "a partir de %d",
+		Through:        "%s a %s",
+		WeekdayThrough: "de %s a %s",
+		And:            "y",
+		Or:             "o",
+		OnDays:         "el día %s del mes",
+		OnDaysOf:       "el %s de %s",
+		OnWeekdays:     "cada %s",
+		AndEvery:       "y cada %s",
+		IfWeekday:      "si es %s",
+		InMonths:       "en %s",
+		InYears:        "en %s",
+		LastDay:        "el último día del mes",
+		LastDayOffset:  "el %s último día del mes",
+		NearestWeekday: "el día laborable más cercano al día %s del mes",
+		LastWeekday:    "el último día laborable del mes",
+		LastDayOfWeek:  "el último %s del mes",
+		NthDayOfWeek:   "el %s %s del mes",
+		Macros: map[string]string{
+			"@yearly":   "Cada año a medianoche el 1 de enero",
+			"@annually": "Cada año a medianoche el 1 de enero",
+			"@monthly":  "Cada mes a medianoche el día 1",
+			"@weekly":   "Cada semana a medianoche el domingo",
+			"@daily":    "Cada día a medianoche",
+			"@midnight": "Cada día a medianoche",
+			"@hourly":   "Cada hora al comienzo de la hora",
+			"@reboot":   "Al iniciar el sistema",
+		},
+		Weekdays: [7]string{"lunes", "martes", "miércoles", "jueves", "viernes", "sábado", "domingo"},
+		Months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	},
+	// Spanish writes ordinals with a masculine ordinal indicator, such as "3.º", and dates with plain numbers
+	Ordinal: func(n int) string { return strconv.Itoa(n) + ".º" },
+	Day:     strconv.Itoa,
+	Plural:  func(n int) bool { return n != 1 },
+}
@@ -0,0 +1,80 @@
+package meaning
+
+import (
+	"strconv"
+)
+
+// French writes explanations in French
+var French = &Locale{
+	Tag: "fr",
+	Messages: Catalog{
+		At:             "à %s",
+		EverySecond:    "chaque seconde",
+		EveryMinute:    "chaque minute",
+		EveryHour:      "chaque heure",
+		EverySeconds:   "toutes les %d secondes",
+		EveryMinutes:   "toutes les %d minutes",
+		EveryHours:     "toutes les %d heures",
+		EveryDays:      "tous les %d jours",
+		EveryMonths:    "tous les %d mois",
+		EveryYears:     "tous les %d ans",
+		AtSeconds:      Forms{One: "à la seconde %s", Other: "à la seconde %s", List: "aux secondes %s"},
+		AtMinutes:      Forms{One: "à la minute %s de chaque heure", Other: "à la minute %s de chaque heure", List: "aux minutes %s de chaque heure"},
+		BetweenSeconds: "entre les secondes %s et %s",
+		BetweenMinutes: "entre les minutes %s et %s",
+		StartingSecond: "à partir de la seconde %s",
+		StartingMinute: "à partir de la minute %s",
+		StartingHour:   "à partir de %s",
+		StartingDay:    "à partir du %s du mois",
+		StartingMonth:  "à partir de %s",
+		Between:        "entre %s et %s",
+		BetweenDays:    "entre le %s et le %s du mois",
+		BetweenMonths:  "entre %s et %s",
+		FromThrough:    "de %s à %s",
+		DuringHours:    "pendant les heures de %s",
+		FromYear:       "à partir de %d",
+		Through:        "%s à %s",
+		WeekdayThrough: "du %s au %s",
+		And:            "et",
+		Or:             "ou",
+		OnDays:         "le %s du mois",
+		OnDaysOf:       "le %s %s",
+		OnWeekdays:     "le %s",
+		AndEvery:       "et chaque %s",
+		IfWeekday:      "si c'est un %s",
+		InMonths:       "en %s",
+		InYears:        "en %s",
+		LastDay:        "le dernier jour du mois",
+		LastDayOffset:  "le %s dernier jour du mois",
+		NearestWeekday: "le jour ouvré le plus proche du %s du mois",
+		LastWeekday:    "le dernier jour ouvré du mois",
+		LastDayOfWeek:  "le dernier %s du mois",
+		NthDayOfWeek:   "le %s %s du mois",
+		Macros: map[string]string{
+			"@yearly":   "Chaque année à minuit le 1er janvier",
+			"@annually": "Chaque année à minuit le 1er janvier",
+			"@monthly":  "Chaque mois à minuit le 1er",
+			"@weekly":   "Chaque semaine à minuit le dimanche",
+			"@daily":    "Chaque jour à minuit",
+			"@midnight": "Chaque jour à minuit",
+			"@hourly":   "Chaque heure au début de l'heure",
+			"@reboot":   "Au démarrage du système",
+		},
+		Weekdays: [7]string{"lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi", "dimanche"},
+		Months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	},
+	// French writes the first as "1er" and other ordinals with "e", such as "3e", but dates with plain numbers after the first, such as "le 15"
+	Ordinal: func(n int) string {
+		if n == 1 {
+			return "1er"
+		}
+		return strconv.Itoa(n) + "e"
+	},
+	Day: func(n int) string {
+		if n == 1 {
+			return "1er"
+		}
+		return strconv.Itoa(n)
+	},
+	Plural: func(n int) bool { return n > 1 },
+}
@@ -0,0 +1,70 @@
+package meaning
+
+import (
+	"strconv"
+)
+
+// Portuguese writes explanations in Portuguese
+var Portuguese = &Locale{
+	Tag: "pt",
+	Messages: Catalog{
+		At:             "às %s",
+		EverySecond:    "a cada segundo",
+		EveryMinute:    "a cada minuto",
+		EveryHour:      "a cada hora",
+		EverySeconds:   "a cada %d segundos",
+		EveryMinutes:   "a cada %d minutos",
+		EveryHours:     "a cada %d horas",
+		EveryDays:      "a cada %d dias",
+		EveryMonths:    "a cada %d meses",
+		EveryYears:     "a cada %d anos",
+		AtSeconds:      Forms{One: "no segundo %s", Other: "no segundo %s", List: "nos segundos %s"},
+		AtMinutes:      Forms{One: "no minuto %s de cada hora", Other: "no minuto %s de cada hora", List: "nos minutos %s de cada hora"},
+		BetweenSeconds: "entre os segundos %s e %s",
+		BetweenMinutes: "entre os minutos %s e %s",
+		StartingSecond: "a partir do segundo %s",
+		StartingMinute: "a partir do minuto %s",
+		StartingHour:   "a partir das %s",
+		StartingDay:    "a partir do dia %s do mês",
+		StartingMonth:  "a partir de %s",
+		Between:        "entre %s e %s",
+		BetweenDays:    "entre o dia %s e o dia %s do mês",
+		BetweenMonths:  "entre %s e %s",
+		FromThrough:    "das %s às %s",
+		DuringHours:    "durante as horas das %s",
+		FromYear:       "a partir de %d",
+		Through:        "%s a %s",
+		WeekdayThrough: "de %s a %s",
+		And:            "e",
+		Or:             "ou",
+		OnDays:         "no dia %s do mês",
+		OnDaysOf:       "em %s de %s",
+		OnWeekdays:     "a cada %s",
+		AndEvery:       "e a cada %s",
+		IfWeekday:      "se for %s",
+		InMonths:       "em %s",
+		InYears:        "em %s",
+		LastDay:        "no último dia do mês",
+		LastDayOffset:  "no %sº último dia do mês",
+		NearestWeekday: "no dia útil mais próximo do dia %s do mês",
+		LastWeekday:    "no último dia útil do mês",
+		LastDayOfWeek:  "na última ocorrência de %s no mês",
+		NthDayOfWeek:   "na %sª ocorrência de %s no mês",
+		Macros: map[string]string{
+			"@yearly":   "Todo ano à meia-noite em 1 de janeiro",
+			"@annually": "Todo ano à meia-noite em 1 de janeiro",
+			"@monthly":  "Todo mês à meia-noite no dia 1",
+			"@weekly":   "Toda semana à meia-noite no domingo",
+			"@daily":    "Todo dia à meia-noite",
+			"@midnight": "Todo dia à meia-noite",
+			"@hourly":   "A cada hora no início da hora",
+			"@reboot":   "Na inicialização do sistema",
+		},
+		Weekdays: [7]string{"segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado", "domingo"},
+		Months:   [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+	},
+	// Portuguese ordinals agree in gender with their noun, so the templates write the ordinal indicator after the plain number
+	Ordinal: strconv.Itoa,
+	Day:     strconv.Itoa,
+	Plural:  func(n int) bool { return n > 1 },
+}
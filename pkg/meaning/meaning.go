@@ -1,7 +1,6 @@
 package meaning
 
 import (
-	"fmt"
 	"github.com/dark-enstein/crontable/pkg/reader"
 	"golang.org/x/exp/slices"
 	"io"
@@ -9,31 +8,37 @@ import (
 	"strings"
 )
 
+// Units the fields of reader.CronExpressionDecoded are counted in
 const (
+	Second     = "second"
 	Minute     = "minute"
 	Hour       = "hour"
-	DayOfMonth = "month"
+	DayOfMonth = "day of the month"
 	Month      = "month"
 	DayOfWeek  = "day of the week"
+	Year       = "year"
 )
 
 const (
-	TextWildCard = "every %v"
-	TextEvery    = "every %v of the %v"
-	TextNone     = "on the %v %v"
-	TextComma    = "on the %v and %v %v"
-	TextCommaPre = "on the "
-	TextRange    = "between the %v and %v %v"
+	TextLastDay        = "on the last day of the month"
+	TextLastDayOffset  = "on the %v last day of the month"
+	TextNearestWeekday = "on the weekday nearest the %v of the month"
+	TextLastWeekday    = "on the last weekday of the month"
+	TextLastDayOfWeek  = "on the last %v of the month"
+	TextNthDayOfWeek   = "on the %v %v of the month"
 )
 
-// Just for ref, not very usable
-//var (
-//	min        = "on the 5th and 9th minute"
-//	hour       = "between the 3rd and 19th hour"
-//	dayOfMonth = "every 5th of the month"
-//	month      = "on the 9th month"
-//	dayOfWeek  = "every day of the week"
-//)
+// TextMacro holds the explanation of each nickname accepted in place of a cron expression
+var TextMacro = map[string]string{
+	"@yearly":   "Every year at midnight on the 1st of January",
+	"@annually": "Every year at midnight on the 1st of January",
+	"@monthly":  "Every month at midnight on the 1st",
+	"@weekly":   "Every week at midnight on Sunday",
+	"@daily":    "Every day at midnight",
+	"@midnight": "Every day at midnight",
+	"@hourly":   "Every hour at the start of the hour",
+	"@reboot":   "At system startup",
+}
 
 type Writer interface {
 }
@@ -45,108 +50,58 @@ func Write(exit io.Writer, s []byte) (int, error) {
 	return exit.Write(s)
 }
 
-// Explain parses reader.CronExpressionDecoded into a meaningful response, and returns a byte slice
+// Explain words reader.CronExpressionDecoded as an English sentence, such as "At 09:00 on Saturday" or "Every 15 minutes between 09:00 and 17:59,
+// Monday through Friday", and returns it as a byte slice. Minutes and hours merge into clock times where there are few of them, fields matching every
+// value go unsaid, and steps, lists and ranges read as such. Nicknames such as @daily are explained by their own fixed sentence.
+// Days of the week are counted as Quartz counts them, from Sunday as 1, for expressions with a seconds or a year field, and as Vixie cron does otherwise;
+// use ExplainSchedule to count them as the dialect of a schedule does, and the methods of a Locale to explain in another language.
 func Explain(dec *reader.CronExpressionDecoded) []byte {
-	// Get ordered keys so that I can range over the map in an orderly manner
-	keys, mapDec := dec.FlattenToMap()
-	chain := []string{}
-	for i := 0; i < len(keys); i++ {
-		k := keys[i]
-		v := *mapDec[k]
-		switch k {
-		case reader.Minute:
-			suffix := Minute
-			// resolve struct and ordinals
-			chunkInterface := []interface{}{}
-			lowOrd, highOrd, structure, _ := resolveAll(v)
-			if v.DelimKind != reader.DelimWildcard {
-				chunkInterface = append(chunkInterface, lowOrd)
-			}
-			chunk := ""
-			if v.DelimKind != reader.DelimNone && v.DelimKind != reader.DelimEvery && v.DelimKind != reader.DelimWildcard {
-				for i := 0; i < len(highOrd); i++ {
-					chunkInterface = append(chunkInterface, highOrd[i])
-				}
-			}
-			chunkInterface = append(chunkInterface, suffix)
-			chunk += fmt.Sprintf(structure, chunkInterface...)
-			chain = append(chain, chunk)
-		case reader.Hour:
-			suffix := Hour
-			// resolve struct and ordinals
-			chunkInterface := []interface{}{}
-			lowOrd, highOrd, structure, _ := resolveAll(v)
-			if v.DelimKind != reader.DelimWildcard {
-				chunkInterface = append(chunkInterface, lowOrd)
-			}
-			chunk := ""
-			if v.DelimKind != reader.DelimNone && v.DelimKind != reader.DelimEvery && v.DelimKind != reader.DelimWildcard {
-				for i := 0; i < len(highOrd); i++ {
-					chunkInterface = append(chunkInterface, highOrd[i])
-				}
-			}
-			chunkInterface = append(chunkInterface, suffix)
-			chunk += fmt.Sprintf(structure, chunkInterface...)
-			chain = append(chain, chunk)
-		case reader.DayOfTheMonth:
-			suffix := DayOfMonth
-			// resolve struct and ordinals
-			chunkInterface := []interface{}{}
-			lowOrd, highOrd, structure, _ := resolveAll(v)
-			if v.DelimKind != reader.DelimWildcard {
-				chunkInterface = append(chunkInterface, lowOrd)
-			}
-			chunk := ""
-			if v.DelimKind != reader.DelimNone && v.DelimKind != reader.DelimEvery && v.DelimKind != reader.DelimWildcard {
-				for i := 0; i < len(highOrd); i++ {
-					chunkInterface = append(chunkInterface, highOrd[i])
-				}
-			}
-			chunkInterface = append(chunkInterface, suffix)
-			chunk += fmt.Sprintf(structure, chunkInterface...)
-			chain = append(chain, chunk)
-		case reader.Month:
-			suffix := Month
-			// resolve struct and ordinals
-			chunkInterface := []interface{}{}
-			lowOrd, highOrd, structure, _ := resolveAll(v)
-			if v.DelimKind != reader.DelimWildcard {
-				chunkInterface = append(chunkInterface, lowOrd)
-			}
-			chunk := ""
-			if v.DelimKind != reader.DelimNone && v.DelimKind != reader.DelimEvery && v.DelimKind != reader.DelimWildcard {
-				for i := 0; i < len(highOrd); i++ {
-					chunkInterface = append(chunkInterface, highOrd[i])
-				}
-			}
-			chunkInterface = append(chunkInterface, suffix)
-			chunk += fmt.Sprintf(structure, chunkInterface...)
-			chain = append(chain, chunk)
-		case reader.DayOfTheWeek:
-			suffix := DayOfWeek
-			// resolve struct and ordinals
-			chunkInterface := []interface{}{}
-			lowOrd, highOrd, structure, _ := resolveAll(v)
-			if v.DelimKind != reader.DelimWildcard {
-				chunkInterface = append(chunkInterface, lowOrd)
-			}
-			chunk := ""
-			if v.DelimKind != reader.DelimNone && v.DelimKind != reader.DelimEvery && v.DelimKind != reader.DelimWildcard {
-				for i := 0; i < len(highOrd); i++ {
-					chunkInterface = append(chunkInterface, highOrd[i])
-				}
-			}
-			chunkInterface = append(chunkInterface, suffix)
-			chunk += fmt.Sprintf(structure, chunkInterface...)
-			chain = append(chain, chunk)
-		}
+	return English.Explain(dec)
+}
+
+// ExplainSchedule words a schedule as Explain does, counting days of the week and combining the day fields as the dialect of the schedule does
+func ExplainSchedule(s *reader.Schedule) []byte {
+	return English.ExplainSchedule(s)
+}
+
+// Explain words reader.CronExpressionDecoded as the package-level Explain does, in the language of the locale
+func (l *Locale) Explain(dec *reader.CronExpressionDecoded) []byte {
+	if dec.Second != nil || dec.Year != nil {
+		return l.explain(dec, 1, false)
+	}
+	return l.explain(dec, 0, true)
+}
+
+// ExplainSchedule words a schedule as the package-level ExplainSchedule does, in the language of the locale
+func (l *Locale) ExplainSchedule(s *reader.Schedule) []byte {
+	d := s.Dialect
+	if d == nil {
+		d = reader.Vixie
+	}
+	sunday := 0
+	if spec, ok := d.Spec(reader.DayOfTheWeek); ok {
+		sunday = spec.Aliases["SUN"]
+	}
+	return l.explain(s.CronExpressionDecoded, sunday, d.Days == reader.DaysEither)
+}
+
+// explain words a decoded expression, counting days of the week from sunday as Sunday. either tells whether restricting both day fields fires on
+// days matching either of them.
+func (l *Locale) explain(dec *reader.CronExpressionDecoded, sunday int, either bool) []byte {
+	if dec.Trigger == reader.TriggerReboot {
+		return []byte(l.Messages.Macros["@reboot"])
+	}
+	if text, ok := l.Messages.Macros[dec.Macro]; ok {
+		return []byte(text)
 	}
-	title := titulate(strings.Join(chain, ", "))
-	return []byte(title)
+	return []byte(plan(l, dec, sunday, either).String())
 }
 
 // titulate helps us be civil, starting the sentence with capital letters
 func titulate(s string) string {
+	if s == "" {
+		return s
+	}
 	sRune := []rune(s)
 	sRune[0] = []rune(strings.ToUpper(string(sRune[0])))[0]
 	return string(sRune)
@@ -171,58 +126,3 @@ func NorminalToOrdinal(i int) string {
 		}
 	}
 }
-
-// resolveAll helps orchestrate the inner-workings of Explain. It is like the "kubernetes of netflix"
-func resolveAll(v reader.Catcher) (string, []string, string, error) {
-	lowOrd, structure := "", ""
-	highOrd := make([]string, len(v.High), len(v.High))
-	switch v.DelimKind {
-	case reader.DelimWildcard:
-		structure = TextWildCard
-	case reader.DelimEvery:
-		structure = TextEvery
-	case reader.DelimNone:
-		structure = TextNone
-	case reader.DelimComma:
-		structure = commaAddMoreRef(TextComma, v.High)
-	case reader.DelimRange:
-		structure = TextRange
-	default:
-		_ = structure
-	}
-
-	// if delim os wildcard, every, none, range
-	if len(v.High) == 1 {
-		if v.Low == v.High[0] {
-			ord := NorminalToOrdinal(v.Low)
-			lowOrd, highOrd[0] = ord, ord
-			return lowOrd, highOrd, structure, nil
-		}
-		lowOrd = NorminalToOrdinal(v.Low)
-		highOrd[0] = NorminalToOrdinal(v.High[0])
-		return lowOrd, highOrd, structure, nil
-	}
-
-	// when delim is comma
-	lowOrd = NorminalToOrdinal(v.Low)
-	for i := 0; i < len(v.High); i++ {
-		highOrd = append(highOrd, NorminalToOrdinal(v.High[i]))
-	}
-	return lowOrd, highOrd, structure, nil
-}
-
-// commaAddMoreRef helps account for some edge cases where more digits can be separately specified for a particular time-block in a cron expression: 3,4,5,2 for 3rd, 4th, 5th and 2nd of the relevant time period
-func commaAddMoreRef(s string, list []int) string {
-	if len(list) <= 2 {
-		return s
-	}
-
-	collab := TextCommaPre
-
-	for i := 2; i < len(list); i++ {
-		aft, found := strings.CutPrefix(s, TextCommaPre)
-		_ = found
-		collab += "%v, " + aft
-	}
-	return collab
-}
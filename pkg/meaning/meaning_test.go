@@ -1,27 +1,26 @@
 package meaning
 
 import (
-	"bytes"
 	"context"
+	"flag"
 	"fmt"
 	"github.com/dark-enstein/crontable/pkg/reader"
 	"github.com/stretchr/testify/suite"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
-type written struct {
-	input    []reader.CronExpressionDecoded
-	expected []string
-}
+// update rewrites the explanations of the golden files in testdata with those Explain gives
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
 
 type CronTab struct {
 	suite.Suite
-	ctx       context.Context
-	log       *log.Logger
-	testState *written
-	norminal  *map[int]string
+	ctx      context.Context
+	log      *log.Logger
+	norminal *map[int]string
 }
 
 // NorminalInts holds a map of nominal integers and their expected ordinal format
@@ -29,119 +28,83 @@ var NorminalInts = map[int]string{
 	13: "13th", 7: "7th", 22: "22nd", 8: "8th", 31: "31st", 11: "11th", 28: "28th", 2: "2nd", 18: "18th", 25: "25th", 6: "6th", 30: "30th", 20: "20th", 14: "14th", 29: "29th", 10: "10th", 1: "1st", 5: "5th", 16: "16th", 26: "26th", 23: "23rd", 4: "4th", 3: "3rd", 19: "19th", 15: "15th", 12: "12th", 21: "21st", 27: "27th", 24: "24th", 9: "9th",
 }
 
-var CronDecodedTestInputs = written{
-	[]reader.CronExpressionDecoded{
-		{
-			Minute: reader.Catcher{
-				Low:       5,
-				High:      []int{9},
-				DelimKind: reader.DelimComma,
-			},
-			Hour: reader.Catcher{
-				Low:       3,
-				High:      []int{19},
-				DelimKind: reader.DelimRange,
-			},
-			DayOfMonth: reader.Catcher{
-				Low:       5,
-				High:      []int{5},
-				DelimKind: reader.DelimEvery,
-			},
-			Month: reader.Catcher{
-				Low:       5,
-				High:      []int{5},
-				DelimKind: reader.DelimNone,
-			},
-			DayOfWeek: reader.Catcher{
-				Low:       0,
-				High:      []int{0},
-				DelimKind: reader.DelimWildcard,
-			},
-		},
-		{
-			Minute: reader.Catcher{
-				Low:       5,
-				High:      []int{9},
-				DelimKind: reader.DelimComma,
-			},
-			Hour: reader.Catcher{
-				Low:       3,
-				High:      []int{19},
-				DelimKind: reader.DelimRange,
-			},
-			DayOfMonth: reader.Catcher{
-				Low:       5,
-				High:      []int{5},
-				DelimKind: reader.DelimEvery,
-			},
-			Month: reader.Catcher{
-				Low:       5,
-				High:      []int{5},
-				DelimKind: reader.DelimNone,
-			},
-			DayOfWeek: reader.Catcher{
-				Low:       0,
-				High:      []int{0},
-				DelimKind: reader.DelimWildcard,
-			},
-		},
-		{
-			Minute: reader.Catcher{
-				Low:       10,
-				High:      []int{9},
-				DelimKind: reader.DelimComma,
-			},
-			Hour: reader.Catcher{
-				Low:       1,
-				High:      []int{30},
-				DelimKind: reader.DelimRange,
-			},
-			DayOfMonth: reader.Catcher{
-				Low:       6,
-				High:      []int{6},
-				DelimKind: reader.DelimEvery,
-			},
-			Month: reader.Catcher{
-				Low:       9,
-				High:      []int{9},
-				DelimKind: reader.DelimNone,
-			},
-			DayOfWeek: reader.Catcher{
-				Low:       6,
-				High:      []int{6},
-				DelimKind: reader.DelimWildcard,
-			},
-		},
-	},
-	[]string{
-		"On the 5th and 9th minute, between the 3rd and 19th hour, every 5th of the month, on the 5th month, every day of the week",
-		"On the 5th and 9th minute, between the 3rd and 19th hour, every 5th of the month, on the 5th month, every day of the week",
-		"On the 10th and 9th minute, between the 1st and 30th hour, every 6th of the month, on the 9th month, every day of the week",
-	},
-}
-
 func (c *CronTab) SetupTest() {
 	c.log = log.New(os.Stdout, "crontable: ", log.LstdFlags)
 	log.Println("Starting tests...")
 	c.ctx = context.Background()
-	c.testState = &CronDecodedTestInputs
 	c.norminal = &NorminalInts
 
 	c.log.Println("Tests startup complete...")
 }
 
-// TestCronDecoded tests the Write function
-func (c *CronTab) TestCronExplain() {
-	log := c.log
-	for i := 0; i < len(c.testState.input); i++ {
-		log.Printf("Decoding test input %v", c.testState.input[i])
-		var resBytes bytes.Buffer
-		_, err := Write(&resBytes, Explain(&c.testState.input[i]))
-		c.Assert().NoError(err)
-		c.Assert().Equal(c.testState.expected[i], resBytes.String(), fmt.Sprintf("expected %v, got %v. input: %v", c.testState.expected[i], resBytes.String(), c.testState.input[i]))
+// TestExplainGolden tests the explanation of every expression of the golden files in testdata, each read in the dialect the file is named after
+// and explained in the language its name is tagged with, such as French for vixie.fr.golden, or English for untagged names
+func (c *CronTab) TestExplainGolden() {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.golden"))
+	c.Require().NoError(err)
+	c.Require().NotEmpty(files)
+	for _, file := range files {
+		name, tag, tagged := strings.Cut(strings.TrimSuffix(filepath.Base(file), ".golden"), ".")
+		d, err := reader.LookupDialect(name)
+		c.Require().NoError(err, file)
+		l := English
+		if tagged {
+			l, err = LookupLocale(tag)
+			c.Require().NoError(err, file)
+		}
+		text, err := os.ReadFile(file)
+		c.Require().NoError(err)
+
+		lines := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+			expr, expected, ok := strings.Cut(line, "\t")
+			c.Require().True(ok, "%s:%d: expected an expression and its explanation separated by a tab", file, i+1)
+			sched, err := d.Parse(expr)
+			c.Require().NoError(err, "%s:%d", file, i+1)
+			got := string(l.ExplainSchedule(sched))
+			if *update {
+				lines[i] = expr + "\t" + got
+				continue
+			}
+			c.Assert().Equal(expected, got, "%s:%d: %s", file, i+1, expr)
+		}
+		if *update {
+			c.Require().NoError(os.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0o644))
+		}
 	}
 }
 
+// TestExplain tests that Explain counts days of the week as Quartz does for expressions with seconds, and as Vixie cron does otherwise
+func (c *CronTab) TestExplain() {
+	read := reader.CronRead("0 9 * * 1")
+	c.Assert().Equal("At 09:00 on Monday", string(Explain(read.Decode())))
+
+	read = reader.CronRead("0 0 9 ? * 1")
+	c.Assert().Equal("At 09:00 on Sunday", string(Explain(read.DecodeQuartz())))
+
+	read = reader.CronRead("0 9 1 * 1")
+	c.Assert().Equal("At 09:00 on the 1st of the month and every Monday", string(Explain(read.Decode())))
+}
+
+// TestExplainBuilt tests that expressions built through the Low, High and DelimKind of their fields are explained from them, without Spans
+func (c *CronTab) TestExplainBuilt() {
+	c.Assert().Equal("Every minute", string(Explain(&reader.CronExpressionDecoded{})))
+
+	dec := &reader.CronExpressionDecoded{
+		Minute:    reader.Catcher{Low: 30, High: []int{30}, DelimKind: reader.DelimNone},
+		Hour:      reader.Catcher{Low: 9, High: []int{17}, DelimKind: reader.DelimRange},
+		DayOfWeek: reader.Catcher{Low: 1, High: []int{3, 5}, DelimKind: reader.DelimComma},
+	}
+	c.Assert().Equal("Every hour between 09:30 and 17:30 on Monday, Wednesday and Friday", string(Explain(dec)))
+
+	// a range running backwards matches no minute, which must not stop the explanation
+	dec.Minute = reader.Catcher{Low: 40, High: []int{20}, DelimKind: reader.DelimRange}
+	c.Assert().NotPanics(func() { Explain(dec) })
+}
+
 // TestNorminalToOrdinal tests the NorminalToOrdinal function
 func (c *CronTab) TestNorminalToOrdinal() {
 	log := c.log
@@ -152,6 +115,60 @@ func (c *CronTab) TestNorminalToOrdinal() {
 	}
 }
 
+// TestExplainMacro tests that nicknames are explained by their own sentence
+func (c *CronTab) TestExplainMacro() {
+	c.Assert().Equal("At system startup", string(Explain(&reader.CronExpressionDecoded{Macro: "@reboot", Trigger: reader.TriggerReboot})))
+
+	daily := reader.CronRead("@daily")
+	c.Assert().Equal("Every day at midnight", string(Explain(daily.Decode())))
+}
+
+// TestLookupLocale tests that locales are looked up by language, from regional and POSIX tags alike, and that every locale explains every nickname
+func (c *CronTab) TestLookupLocale() {
+	for tag, expected := range map[string]*Locale{"en": English, "FR": French, "de-AT": German, "es_MX.UTF-8": Spanish, "pt-BR": Portuguese} {
+		l, err := LookupLocale(tag)
+		c.Require().NoError(err, tag)
+		c.Assert().Same(expected, l, tag)
+	}
+	_, err := LookupLocale("xx")
+	c.Assert().ErrorIs(err, ErrUnknownLocale)
+
+	for tag, l := range Locales {
+		for macro := range TextMacro {
+			c.Assert().NotEmpty(l.Messages.Macros[macro], "%s: %s", tag, macro)
+		}
+	}
+	daily := reader.CronRead("@daily")
+	c.Assert().Equal("Chaque jour à minuit", string(French.Explain(daily.Decode())))
+}
+
+// TestExplainQuartz tests that the seconds and years of Quartz expressions are explained
+func (c *CronTab) TestExplainQuartz() {
+	read := reader.CronRead("30 0 12 ? * 2 2026,2027")
+	c.Assert().Equal("At 12:00:30 on Monday in 2026 and 2027", string(Explain(read.DecodeQuartz())))
+
+	read = reader.CronRead("0 0 12 1 * ?")
+	c.Assert().Equal("At 12:00 on the 1st of the month", string(Explain(read.DecodeQuartz())))
+}
+
+// TestExplainQuartzSpecials tests that the Quartz special terms of the day fields are explained
+func (c *CronTab) TestExplainQuartzSpecials() {
+	cases := map[string]string{
+		"0 0 12 L * ?":   "on the last day of the month",
+		"0 0 12 L-3 * ?": "on the 4th last day of the month",
+		"0 0 12 15W * ?": "on the weekday nearest the 15th of the month",
+		"0 0 12 LW * ?":  "on the last weekday of the month",
+		"0 0 12 ? * 6L":  "on the last Friday of the month",
+		"0 0 12 ? * 6#3": "on the 3rd Friday of the month",
+	}
+	for expr, expected := range cases {
+		read := reader.CronRead(expr)
+		dec := read.DecodeQuartz()
+		c.Require().NotNil(dec, expr)
+		c.Assert().Contains(string(Explain(dec)), expected, expr)
+	}
+}
+
 func (c *CronTab) TearDownSuite() {
 	log := c.log
 	log.Println("Commencing test cleanup")
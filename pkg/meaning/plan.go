@@ -0,0 +1,451 @@
+package meaning
+
+import (
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"strconv"
+	"strings"
+)
+
+// maxClockTimes bounds how many clock times a schedule is listed by, such as "At 09:00 and 17:30", before its minutes and hours are worded apart
+const maxClockTimes = 6
+
+// phrase is a part of a sentence, along with what separates it from the part before it
+type phrase struct {
+	sep  string
+	text string
+}
+
+// sentence is an explanation planned as a sequence of phrases, worded in a locale
+type sentence struct {
+	l       *Locale
+	m       *Catalog
+	phrases []phrase
+}
+
+func (s *sentence) add(sep, text string) {
+	s.phrases = append(s.phrases, phrase{sep: sep, text: text})
+}
+
+// String joins the phrases of the sentence and capitalizes it
+func (s *sentence) String() string {
+	var b strings.Builder
+	for i, p := range s.phrases {
+		if i > 0 {
+			b.WriteString(p.sep)
+		}
+		b.WriteString(p.text)
+	}
+	return titulate(b.String())
+}
+
+// field is a field of a cron expression as the planner words it: the values it expands to and the Quartz special terms it holds, out of the values
+// from low to high the field can take
+type field struct {
+	c        *reader.Catcher
+	vals     []int
+	specials []reader.Span
+	low      int
+	high     int
+}
+
+// newField reads the catcher of a field taking values from low to high, from its Low, High and DelimKind where it was built or changed through them
+func newField(c *reader.Catcher, low, high int) *field {
+	resolved := c.Resolved(low, high)
+	c = &resolved
+	f := &field{c: c, vals: c.Values(), low: low, high: high}
+	for _, span := range c.Spans {
+		switch span.Kind {
+		case reader.DelimLast, reader.DelimWeekday, reader.DelimLastWeekday, reader.DelimNth:
+			f.specials = append(f.specials, span)
+		}
+	}
+	return f
+}
+
+// all reports whether the field matches every value it can take, leaving it unrestricted
+func (f *field) all() bool {
+	return len(f.specials) == 0 && len(f.vals) == f.high-f.low+1
+}
+
+// single reports whether the field matches a single value
+func (f *field) single() bool {
+	return len(f.specials) == 0 && len(f.vals) == 1
+}
+
+// plain reports whether the field is a list of single values, without ranges, steps or special terms
+func (f *field) plain() bool {
+	if len(f.specials) > 0 || len(f.vals) == 0 {
+		return false
+	}
+	for _, span := range f.c.Spans {
+		if span.Low != span.High {
+			return false
+		}
+	}
+	return true
+}
+
+// step returns the term of a field made of a single stepped term, such as "*/15" or "0-20/2"
+func (f *field) step() (reader.Span, bool) {
+	if len(f.c.Spans) != 1 || f.c.Spans[0].Step < 2 {
+		return reader.Span{}, false
+	}
+	return f.c.Spans[0], true
+}
+
+// run returns the first and last values of a field matching a single run of two or more consecutive values, such as "9-17"
+func (f *field) run() (int, int, bool) {
+	if _, ok := f.step(); ok || len(f.specials) > 0 || len(f.vals) < 2 {
+		return 0, 0, false
+	}
+	first, last := f.vals[0], f.vals[len(f.vals)-1]
+	return first, last, last-first+1 == len(f.vals)
+}
+
+// plan plans the explanation of a schedule that fires at times in the locale l. Days of the week count from sunday as Sunday, and either tells whether
+// restricting both day fields fires on days matching either of them, as Vixie cron does, rather than on days matching both.
+func plan(l *Locale, dec *reader.CronExpressionDecoded, sunday int, either bool) *sentence {
+	s := &sentence{l: l, m: &l.Messages}
+	var sec *field
+	if dec.Second != nil {
+		sec = newField(dec.Second, 0, 59)
+	}
+	min := newField(&dec.Minute, 0, 59)
+	hour := newField(&dec.Hour, 0, 23)
+	s.clock(sec, min, hour)
+
+	dom := newField(&dec.DayOfMonth, 1, 31)
+	month := newField(&dec.Month, 1, 12)
+	dow := newField(&dec.DayOfWeek, sunday, sunday+6)
+	either = either && dec.DayOfMonth.Restricted() && dec.DayOfWeek.Restricted()
+	s.days(dom, month, dow, sunday, either)
+	if dec.Year != nil {
+		s.years(newField(dec.Year, 1970, 2099))
+	}
+	return s
+}
+
+// clock plans the time of day a schedule fires at: as clock times where there are few of them, and otherwise second, minute and hour apart
+func (s *sentence) clock(sec, min, hour *field) {
+	seconds := sec != nil && !(sec.single() && sec.vals[0] == 0)
+	if (!seconds || sec.plain()) && min.plain() && hour.plain() {
+		n := len(min.vals) * len(hour.vals)
+		if seconds {
+			n *= len(sec.vals)
+		}
+		if n <= maxClockTimes {
+			var times []string
+			for _, h := range hour.vals {
+				for _, m := range min.vals {
+					if !seconds {
+						times = append(times, clock(h, m))
+						continue
+					}
+					for _, sv := range sec.vals {
+						times = append(times, clock(h, m)+fmt.Sprintf(":%02d", sv))
+					}
+				}
+			}
+			s.add("", fmt.Sprintf(s.m.At, s.list(times, s.m.And)))
+			return
+		}
+	}
+	if seconds {
+		s.seconds(sec)
+	}
+	s.minutes(min, hour, seconds)
+}
+
+// seconds plans the seconds a schedule fires at
+func (s *sentence) seconds(sec *field) {
+	switch first, last, isRun := sec.run(); {
+	case sec.all():
+		s.add("", s.m.EverySecond)
+	case sec.single():
+		s.add("", s.forms(s.m.AtSeconds, sec.vals))
+	case isRun:
+		s.add("", s.m.EverySecond+" "+fmt.Sprintf(s.m.BetweenSeconds, strconv.Itoa(first), strconv.Itoa(last)))
+	default:
+		if span, ok := sec.step(); ok {
+			s.add("", fmt.Sprintf(s.m.EverySeconds, span.Step)+s.window(span, sec, strconv.Itoa, s.m.StartingSecond, s.m.BetweenSeconds))
+			return
+		}
+		s.add("", s.forms(s.m.AtSeconds, sec.vals))
+	}
+}
+
+// minutes plans the minutes a schedule fires at, along with its hours. After seconds, minutes matching every value go unsaid.
+func (s *sentence) minutes(min, hour *field, seconds bool) {
+	first, last, isRun := min.run()
+	switch {
+	case min.all() && seconds:
+		s.hours(hour, ", ")
+	case min.all():
+		s.add(", ", s.m.EveryMinute)
+		s.hours(hour, " ")
+	case min.single():
+		m := min.vals[0]
+		hFirst, hLast, hRun := hour.run()
+		span, hStep := hour.step()
+		switch {
+		case hour.single():
+			s.add(", ", fmt.Sprintf(s.m.At, clock(hour.vals[0], m)))
+		case hour.all() && m == 0 && !seconds:
+			s.add(", ", s.m.EveryHour)
+		case hour.all():
+			s.add(", ", s.forms(s.m.AtMinutes, min.vals))
+		case hRun && !seconds:
+			s.add(", ", s.m.EveryHour+" "+fmt.Sprintf(s.m.Between, clock(hFirst, m), clock(hLast, m)))
+		case hStep && m == 0 && !seconds:
+			s.add(", ", fmt.Sprintf(s.m.EveryHours, span.Step)+s.window(span, hour, oClock, s.m.StartingHour, s.m.Between))
+		case !hStep && !seconds:
+			s.add(", ", s.hourly(hour.vals, m))
+		default:
+			s.add(", ", s.forms(s.m.AtMinutes, min.vals))
+			s.hours(hour, ", ")
+		}
+	case isRun && hour.single() && !seconds:
+		s.add(", ", s.m.EveryMinute+" "+fmt.Sprintf(s.m.Between, clock(hour.vals[0], first), clock(hour.vals[0], last)))
+	case isRun:
+		s.add(", ", s.m.EveryMinute+" "+fmt.Sprintf(s.m.BetweenMinutes, strconv.Itoa(first), strconv.Itoa(last)))
+		s.hours(hour, ", ")
+	default:
+		if span, ok := min.step(); ok {
+			s.add(", ", fmt.Sprintf(s.m.EveryMinutes, span.Step)+s.window(span, min, strconv.Itoa, s.m.StartingMinute, s.m.BetweenMinutes))
+			s.hours(hour, " ")
+			return
+		}
+		s.add(", ", s.forms(s.m.AtMinutes, min.vals))
+		s.hours(hour, ", ")
+	}
+}
+
+// hours plans the hours a schedule fires in, after its minutes. Windows of hours follow the minutes after sep.
+func (s *sentence) hours(hour *field, sep string) {
+	first, last, isRun := hour.run()
+	switch {
+	case hour.all():
+	case hour.single():
+		s.add(sep, fmt.Sprintf(s.m.Between, clock(hour.vals[0], 0), clock(hour.vals[0], 59)))
+	case isRun:
+		s.add(sep, fmt.Sprintf(s.m.Between, clock(first, 0), clock(last, 59)))
+	default:
+		if span, ok := hour.step(); ok {
+			s.add(", ", fmt.Sprintf(s.m.EveryHours, span.Step)+s.window(span, hour, oClock, s.m.StartingHour, s.m.Between))
+			return
+		}
+		s.add(", ", fmt.Sprintf(s.m.DuringHours, s.runs(hour.vals, oClock, s.m.Through, s.m.And)))
+	}
+}
+
+// days plans the days a schedule fires on, from its day of month, month and day of week fields
+func (s *sentence) days(dom, month, dow *field, sunday int, either bool) {
+	// firing on days matching either field fires every day when one of them lists every day it can take
+	everyDay := either && (dom.all() || dow.all())
+	monthDone := false
+	var domText string
+	domSep := " "
+	switch {
+	case dom.all() || everyDay:
+	case len(dom.specials) > 0:
+		domText = s.specialDay(dom.specials[0], sunday)
+	default:
+		if span, ok := dom.step(); ok {
+			domSep = ", "
+			domText = fmt.Sprintf(s.m.EveryDays, span.Step) + s.window(span, dom, s.l.Day, s.m.StartingDay, s.m.BetweenDays)
+			break
+		}
+		days := s.runs(dom.vals, s.l.Day, s.m.Through, s.m.And)
+		domText = fmt.Sprintf(s.m.OnDays, days)
+		if !month.all() && len(month.specials) == 0 {
+			if _, stepped := month.step(); !stepped {
+				domText, monthDone = fmt.Sprintf(s.m.OnDaysOf, days, s.runs(month.vals, s.monthName, s.m.Through, s.m.And)), true
+			}
+		}
+	}
+
+	positions := weekdayPositions(dow, sunday)
+	names := s.runs(positions, s.weekdayName, s.m.Through, s.m.And)
+	monthSep := " "
+	switch {
+	case dow.all() || everyDay:
+		if domText != "" {
+			s.add(domSep, domText)
+		}
+	case len(dow.specials) > 0:
+		if domText != "" {
+			s.add(domSep, domText+" "+s.m.And)
+		}
+		s.add(" ", s.specialDay(dow.specials[0], sunday))
+	case domText != "" && either:
+		s.add(domSep, domText+" "+fmt.Sprintf(s.m.AndEvery, names))
+	case domText != "":
+		s.add(domSep, domText+" "+fmt.Sprintf(s.m.IfWeekday, s.runs(positions, s.weekdayName, s.m.Through, s.m.Or)))
+	case len(positions) >= 3 && positions[len(positions)-1]-positions[0] == len(positions)-1:
+		s.add(", ", fmt.Sprintf(s.m.WeekdayThrough, s.weekdayName(positions[0]), s.weekdayName(positions[len(positions)-1])))
+		monthSep = ", "
+	default:
+		s.add(" ", fmt.Sprintf(s.m.OnWeekdays, names))
+	}
+
+	if monthDone || month.all() {
+		return
+	}
+	if span, ok := month.step(); ok && span.Low == 1 {
+		s.add(", ", fmt.Sprintf(s.m.EveryMonths, span.Step)+s.window(span, month, s.monthName, s.m.StartingMonth, s.m.BetweenMonths))
+		return
+	}
+	s.add(monthSep, fmt.Sprintf(s.m.InMonths, s.runs(month.vals, s.monthName, s.m.Through, s.m.And)))
+}
+
+// years plans the years a schedule fires in, which read as plain numbers
+func (s *sentence) years(year *field) {
+	if year.c.DelimKind == reader.DelimWildcard {
+		return
+	}
+	if span, ok := year.step(); ok {
+		s.add(", ", fmt.Sprintf(s.m.EveryYears, span.Step)+" "+fmt.Sprintf(s.m.FromYear, span.Low))
+		return
+	}
+	s.add(" ", fmt.Sprintf(s.m.InYears, s.runs(year.vals, strconv.Itoa, s.m.Through, s.m.And)))
+}
+
+// specialDay words a Quartz special term of a day field
+func (s *sentence) specialDay(span reader.Span, sunday int) string {
+	switch span.Kind {
+	case reader.DelimLast:
+		if span.Low > 0 {
+			return fmt.Sprintf(s.m.LastDayOfWeek, s.weekdayName(position(span.Low, sunday)))
+		}
+		if span.Offset > 0 {
+			return fmt.Sprintf(s.m.LastDayOffset, s.l.Ordinal(span.Offset+1))
+		}
+		return s.m.LastDay
+	case reader.DelimWeekday:
+		return fmt.Sprintf(s.m.NearestWeekday, s.l.Day(span.Low))
+	case reader.DelimLastWeekday:
+		return s.m.LastWeekday
+	case reader.DelimNth:
+		return fmt.Sprintf(s.m.NthDayOfWeek, s.l.Ordinal(span.Nth), s.weekdayName(position(span.Low, sunday)))
+	}
+	return ""
+}
+
+// weekdayPositions returns the days of the week a field matches counted from Monday as 0, in order
+func weekdayPositions(dow *field, sunday int) []int {
+	seen := make([]bool, 7)
+	for _, v := range dow.vals {
+		seen[position(v, sunday)] = true
+	}
+	var positions []int
+	for i, ok := range seen {
+		if ok {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// position counts a day of the week numbered from sunday as Sunday from Monday as 0 instead
+func position(v, sunday int) int {
+	return ((v-sunday+6)%7 + 7) % 7
+}
+
+func (s *sentence) weekdayName(position int) string {
+	return s.m.Weekdays[position]
+}
+
+func (s *sentence) monthName(v int) string {
+	if v < 1 || v > len(s.m.Months) {
+		return strconv.Itoa(v)
+	}
+	return s.m.Months[v-1]
+}
+
+// clock writes an hour and a minute as a 24-hour clock time, such as "09:05"
+func clock(h, m int) string {
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+// oClock writes the clock time an hour starts at, such as "09:00"
+func oClock(h int) string {
+	return clock(h, 0)
+}
+
+// forms words values with the form of a message matching how many there are and, for a single value, the plural rule of the locale
+func (s *sentence) forms(f Forms, vals []int) string {
+	text := s.runs(vals, strconv.Itoa, s.m.Through, s.m.And)
+	switch {
+	case len(vals) != 1:
+		return fmt.Sprintf(f.List, text)
+	case s.l.Plural(vals[0]):
+		return fmt.Sprintf(f.Other, text)
+	}
+	return fmt.Sprintf(f.One, text)
+}
+
+// hourly words the clock times of a minute within each of a list of hours: at each time, or every hour from one time through another for runs
+// of three or more consecutive hours
+func (s *sentence) hourly(hours []int, m int) string {
+	var terms []string
+	for i := 0; i < len(hours); {
+		j := i
+		for j+1 < len(hours) && hours[j+1] == hours[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			terms = append(terms, s.m.EveryHour+" "+fmt.Sprintf(s.m.FromThrough, clock(hours[i], m), clock(hours[j], m)))
+		} else {
+			for k := i; k <= j; k++ {
+				terms = append(terms, fmt.Sprintf(s.m.At, clock(hours[k], m)))
+			}
+		}
+		i = j + 1
+	}
+	return s.list(terms, s.m.And)
+}
+
+// window words where a stepped term starts and ends when it does not span the whole field: by the starting template for terms running to the end
+// of the field, and by the between template otherwise, with values written by name
+func (s *sentence) window(span reader.Span, f *field, name func(int) string, starting, between string) string {
+	switch {
+	case span.Low == f.low && span.High >= f.high:
+		return ""
+	case span.High >= f.high:
+		return " " + fmt.Sprintf(starting, name(span.Low))
+	}
+	return " " + fmt.Sprintf(between, name(span.Low), name(span.High))
+}
+
+// runs words sorted values as a list joined by conj, writing each run of three or more consecutive values by the through template, such as
+// "Monday through Friday"
+func (s *sentence) runs(vals []int, name func(int) string, through, conj string) string {
+	var terms []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			terms = append(terms, fmt.Sprintf(through, name(vals[i]), name(vals[j])))
+		} else {
+			for k := i; k <= j; k++ {
+				terms = append(terms, name(vals[k]))
+			}
+		}
+		i = j + 1
+	}
+	return s.list(terms, conj)
+}
+
+// list joins terms with commas and conj before the last: "a", "a and b", "a, b and c"
+func (s *sentence) list(terms []string, conj string) string {
+	switch len(terms) {
+	case 0:
+		return ""
+	case 1:
+		return terms[0]
+	}
+	return strings.Join(terms[:len(terms)-1], ", ") + " " + conj + " " + terms[len(terms)-1]
+}
@@ -0,0 +1,144 @@
+package phrase
+
+import (
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// expression writes the fields read from a description as a cron expression, along with the dialect it is written in. Fields left unsaid
+// take their defaults: minutes are 0 for schedules naming hours, and every minute for schedules naming seconds.
+func (p *parser) expression() (string, *reader.Dialect, error) {
+	end := len(p.toks)
+	if err := p.fromClocks(); err != nil {
+		return "", nil, err
+	}
+	if p.second.base == "" && p.second.step == 0 && p.minute.base == "" && p.minute.step == 0 && p.hour.base == "" && p.hour.step == 0 && !p.hourly {
+		return "", nil, p.errorf(ErrAmbiguous, 0, end, "no time of day given; say when it fires, such as \"at 09:00\" or \"every hour\"")
+	}
+	if p.minute.base == "" && p.minute.step == 0 {
+		p.minute.base = "0"
+		if p.second.base != "" || p.second.step != 0 {
+			p.minute.base = "*"
+		}
+	}
+	if p.second.base == "" && p.second.step == 0 {
+		p.second.base = "0"
+	}
+
+	domSet := p.dom.base != "" || p.dom.step != 0
+	dowSet := p.dow.base != ""
+	switch {
+	case p.weekly && !domSet && !dowSet:
+		p.dow.base, dowSet = "SUN", true
+	case p.yearly && !domSet && !dowSet:
+		p.dom.base, domSet = "1", true
+		if p.month.base == "" && p.month.step == 0 {
+			p.month.base = "JAN"
+		}
+	case p.monthly && !domSet && !dowSet:
+		p.dom.base, domSet = "1", true
+	}
+
+	if !p.quartz {
+		p.minute.rangeFrom(59)
+		p.hour.rangeFrom(23)
+		p.dom.rangeFrom(31)
+		p.month.rangeFrom(12)
+	}
+	dom, dow := p.dom.term(), p.dow.term()
+	switch {
+	case p.quartz && domSet && dowSet:
+		return "", nil, p.errorf(ErrUnsupported, 0, end, "Quartz schedules cannot restrict both the day of the month and the day of the week")
+	case p.quartz && dowSet:
+		dom = "?"
+	case p.quartz:
+		dow = "?"
+	case p.both:
+		if !domSet || p.dom.base != "" || p.either {
+			return "", nil, p.errorf(ErrUnsupported, 0, end, "cron can only require days of the week of days stepped through the whole month, such as \"every 2 days if it is a Monday\"")
+		}
+	case domSet && dowSet && (!p.either || p.dom.base == ""):
+		return "", nil, p.errorf(ErrUnsupported, 0, end, "cron cannot fire only on days of the month falling on given days of the week; say \"and every Monday\" to fire on either")
+	}
+
+	// ranges ending on Sunday count it as 7 in Vixie cron, and cannot wrap around in Quartz
+	if strings.Contains(dow, "-SUN") {
+		sunday := "7"
+		if p.quartz {
+			sunday = "SAT,SUN"
+		}
+		dow = strings.ReplaceAll(strings.ReplaceAll(dow, "SAT-SUN", "SAT,SUN"), "-SUN", "-"+sunday)
+	}
+	fields := []string{p.minute.term(), p.hour.term(), dom, p.month.term(), dow}
+	if !p.quartz {
+		return strings.Join(fields, " "), reader.Vixie, nil
+	}
+	fields = append([]string{p.second.term()}, fields...)
+	if p.year.base != "" || p.year.step != 0 {
+		fields = append(fields, p.year.term())
+	}
+	return strings.Join(fields, " "), reader.Quartz, nil
+}
+
+// term writes a field as a cron term, with "*" for a field left unsaid
+func (f *field) term() string {
+	base := f.base
+	if base == "" {
+		base = "*"
+	}
+	if f.step == 0 {
+		return base
+	}
+	return base + "/" + strconv.Itoa(f.step)
+}
+
+// rangeFrom widens a step from a single value, such as "5/15", into a step through the range from it to high, such as "5-59/15", as Vixie cron
+// only steps through ranges
+func (f *field) rangeFrom(high int) {
+	if f.step != 0 && f.base != "" && !strings.ContainsAny(f.base, "*-,") {
+		f.base += "-" + strconv.Itoa(high)
+	}
+}
+
+// fromClocks sets the hours, minutes and seconds from the times of day named, provided every hour named fires at every minute named
+func (p *parser) fromClocks() error {
+	if len(p.clocks) == 0 {
+		return nil
+	}
+	hours, minutes, seconds := map[int]bool{}, map[int]bool{}, map[int]bool{}
+	times := map[clock]bool{}
+	for _, c := range p.clocks {
+		hours[c.h], minutes[c.m], seconds[c.s] = true, true, true
+		times[c] = true
+	}
+	if len(times) != len(hours)*len(minutes)*len(seconds) {
+		return p.errorf(ErrUnsupported, 0, len(p.toks), "cron cannot fire at the times named, as each hour named must fire at every minute named")
+	}
+	if len(seconds) > 1 || !seconds[0] {
+		p.quartz = true
+		if err := p.setClock(&p.second, seconds); err != nil {
+			return err
+		}
+	}
+	if err := p.setClock(&p.minute, minutes); err != nil {
+		return err
+	}
+	return p.setClock(&p.hour, hours)
+}
+
+// setClock sets a field to the values of a set, erroring when other words set it otherwise
+func (p *parser) setClock(f *field, set map[int]bool) error {
+	vals := make([]int, 0, len(set))
+	for v := range set {
+		vals = append(vals, v)
+	}
+	sort.Ints(vals)
+	base := reader.FormatValues(vals)
+	if f.base != "" && f.base != base {
+		return p.errorf(ErrConflict, 0, len(p.toks), "the times named contradict %q", f.words)
+	}
+	f.base = base
+	return nil
+}
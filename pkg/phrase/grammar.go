@@ -0,0 +1,708 @@
+package phrase
+
+import (
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// weekdays maps the words naming days of the week to the cron term they stand for
+var weekdays = map[string]string{
+	"monday": "MON", "mon": "MON", "mondays": "MON",
+	"tuesday": "TUE", "tue": "TUE", "tues": "TUE", "tuesdays": "TUE",
+	"wednesday": "WED", "wed": "WED", "wednesdays": "WED",
+	"thursday": "THU", "thu": "THU", "thurs": "THU", "thursdays": "THU",
+	"friday": "FRI", "fri": "FRI", "fridays": "FRI",
+	"saturday": "SAT", "sat": "SAT", "saturdays": "SAT",
+	"sunday": "SUN", "sun": "SUN", "sundays": "SUN",
+	"weekday": "MON-FRI", "weekdays": "MON-FRI",
+	"weekend": "SAT,SUN", "weekends": "SAT,SUN",
+}
+
+// months maps the words naming months to the cron term they stand for
+var months = map[string]string{
+	"january": "JAN", "jan": "JAN", "february": "FEB", "feb": "FEB", "march": "MAR", "mar": "MAR", "april": "APR", "apr": "APR",
+	"may": "MAY", "june": "JUN", "jun": "JUN", "july": "JUL", "jul": "JUL", "august": "AUG", "aug": "AUG",
+	"september": "SEP", "sep": "SEP", "sept": "SEP", "october": "OCT", "oct": "OCT", "november": "NOV", "nov": "NOV",
+	"december": "DEC", "dec": "DEC",
+}
+
+// unitFields maps the units of time that can be stepped through to the field they step
+var unitFields = map[string]string{
+	"second": reader.Second, "minute": reader.Minute, "hour": reader.Hour, "day": reader.DayOfTheMonth, "month": reader.Month, "year": reader.Year,
+}
+
+// ordinalWords maps the ordinals written as words to their number
+var ordinalWords = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5}
+
+// units maps the words naming units of time to their singular
+var units = map[string]string{
+	"second": "second", "seconds": "second", "sec": "second", "secs": "second",
+	"minute": "minute", "minutes": "minute", "min": "minute", "mins": "minute",
+	"hour": "hour", "hours": "hour", "hr": "hour", "hrs": "hour",
+	"day": "day", "days": "day", "week": "week", "weeks": "week",
+	"month": "month", "months": "month", "year": "year", "years": "year",
+}
+
+var (
+	ordinalPattern = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+	clockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+)
+
+// clock is a time of day
+type clock struct {
+	h, m, s int
+}
+
+// every reads "every" or "each" followed by a unit, a number of units, "other" and a unit, days of the week or months
+func (p *parser) every() (bool, error) {
+	from := p.pos
+	if _, ok := p.acceptAny("every", "each"); !ok {
+		return false, nil
+	}
+	n := 1
+	if p.accept("other") {
+		n = 2
+	} else if v, ok := p.number(); ok {
+		n = v
+	}
+	unit, ok := units[p.peek()]
+	if !ok {
+		if n != 1 {
+			return false, nil
+		}
+		if days, ok := p.list(p.weekday); ok {
+			p.either = true
+			return true, p.set(&p.dow, days, from)
+		}
+		if ms, ok := p.list(p.monthName); ok {
+			return true, p.set(&p.month, ms, from)
+		}
+		return false, nil
+	}
+	p.pos++
+	if n < 1 {
+		return false, p.errorf(ErrUnsupported, from, p.pos, "%q is not a step", p.words(from, p.pos))
+	}
+	if spec, ok := reader.Quartz.Spec(unitFields[unit]); ok && n > spec.High-spec.Low+1 {
+		return false, p.errorf(ErrUnsupported, from, p.pos, "cron cannot fire every %d %ss, as it steps through %d %ss at most", n, unit, spec.High-spec.Low+1, unit)
+	}
+	switch unit {
+	case "second":
+		p.quartz = true
+		return true, p.stepOrAll(&p.second, n, from)
+	case "minute":
+		return true, p.stepOrAll(&p.minute, n, from)
+	case "hour":
+		p.hourly = true
+		if n == 1 {
+			return true, nil
+		}
+		return true, p.setStep(&p.hour, n, from)
+	case "day":
+		if n == 1 {
+			return true, nil
+		}
+		return true, p.setStep(&p.dom, n, from)
+	case "week":
+		if n != 1 {
+			return false, p.errorf(ErrUnsupported, from, p.pos, "cron cannot fire every %d weeks", n)
+		}
+		p.weekly = true
+		return true, nil
+	case "month":
+		if n == 1 {
+			p.monthly = true
+			return true, nil
+		}
+		return true, p.setStep(&p.month, n, from)
+	default:
+		if n == 1 {
+			p.yearly = true
+			return true, nil
+		}
+		p.quartz = true
+		return true, p.setStep(&p.year, n, from)
+	}
+}
+
+// stepOrAll steps a field by n, or sets it to every value for a step of 1
+func (p *parser) stepOrAll(f *field, n, from int) error {
+	if n == 1 {
+		return p.set(f, "*", from)
+	}
+	return p.setStep(f, n, from)
+}
+
+// nicknameWord reads the adverbs standing for a unit: "hourly", "daily", "weekly", "monthly", "yearly" and "annually"
+func (p *parser) nicknameWord() (bool, error) {
+	switch w, _ := p.acceptAny("hourly", "daily", "weekly", "monthly", "yearly", "annually"); w {
+	case "hourly":
+		p.hourly = true
+	case "daily":
+	case "weekly":
+		p.weekly = true
+	case "monthly":
+		p.monthly = true
+	case "yearly", "annually":
+		p.yearly = true
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// at reads "at" followed by times of day, or by minutes past the hour or seconds past the minute
+func (p *parser) at() (bool, error) {
+	from := p.pos
+	if !p.accept("at") {
+		return false, nil
+	}
+	if ok, err := p.past(from, false); ok || err != nil {
+		return ok, err
+	}
+	return p.clockList()
+}
+
+// times reads times of day written without "at", such as "9:30am every weekday"
+func (p *parser) times() (bool, error) {
+	return p.clockList()
+}
+
+// clockList reads a list of times of day, erroring on hours written without minutes or "am" or "pm"
+func (p *parser) clockList() (bool, error) {
+	c, ok, err := p.clock()
+	if err != nil || !ok {
+		if start := p.pos; err == nil {
+			if h, ok := p.number(); ok && h <= 24 {
+				return false, p.errorf(ErrAmbiguous, start, p.pos, "%q could be in the morning or the evening; write %dam, %dpm or a 24-hour time such as %02d:00",
+					p.words(start, p.pos), h, h, h)
+			}
+		}
+		return false, err
+	}
+	clocks := []clock{c}
+	for {
+		mark := p.pos
+		if !p.separator() {
+			break
+		}
+		c, ok, err := p.clock()
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			p.pos = mark
+			break
+		}
+		clocks = append(clocks, c)
+	}
+	p.clocks = append(p.clocks, clocks...)
+	return true, nil
+}
+
+// past reads numbers followed by "minutes past the hour" or "seconds past the minute", setting the minutes or seconds, or only their first value
+// when start is true
+func (p *parser) past(from int, start bool) (bool, error) {
+	mark := p.pos
+	vals, ok := p.list(p.numeral)
+	if !ok {
+		return false, nil
+	}
+	f := p.pastUnit()
+	if f == nil {
+		p.pos = mark
+		return false, nil
+	}
+	if start && strings.ContainsAny(vals, ",-") {
+		return false, p.errorf(ErrUnsupported, from, p.pos, "steps can only start at a single value")
+	}
+	return true, p.set(f, vals, from)
+}
+
+// pastUnit reads "minutes past the hour" or "seconds past the minute", returning the field they count
+func (p *parser) pastUnit() *field {
+	unit := units[p.peek()]
+	if unit != "minute" && unit != "second" {
+		return nil
+	}
+	p.pos++
+	if !p.accept("past") {
+		return nil
+	}
+	if _, ok := p.acceptAny("the", "each", "every"); !ok {
+		return nil
+	}
+	switch {
+	case unit == "minute" && p.accept("hour"):
+		return &p.minute
+	case unit == "second" && p.accept("minute"):
+		p.quartz = true
+		return &p.second
+	}
+	return nil
+}
+
+// starting reads where a step starts: "starting at" minutes past the hour, seconds past the minute or a time of day, "starting on the" day of
+// the month, or "starting in" a month
+func (p *parser) starting() (bool, error) {
+	from := p.pos
+	if !p.accept("starting") {
+		return false, nil
+	}
+	switch w, _ := p.acceptAny("at", "on", "in", "from"); w {
+	case "at", "from":
+		if ok, err := p.past(from, true); ok || err != nil {
+			return ok, err
+		}
+		c, ok, err := p.clock()
+		if !ok || err != nil {
+			return false, err
+		}
+		if c.m != 0 {
+			if err := p.set(&p.minute, strconv.Itoa(c.m), from); err != nil {
+				return false, err
+			}
+		}
+		return true, p.set(&p.hour, strconv.Itoa(c.h), from)
+	case "on":
+		p.accept("the")
+		d, ok := p.ordinal()
+		if !ok || !p.ofMonth() {
+			return false, nil
+		}
+		return true, p.set(&p.dom, strconv.Itoa(d), from)
+	case "in":
+		m, ok := p.monthName()
+		if !ok {
+			return false, nil
+		}
+		return true, p.set(&p.month, m, from)
+	}
+	return false, nil
+}
+
+// between reads the bounds of a window: two times of day, two numbers of minutes past the hour or seconds past the minute, two days of the
+// month or two months
+func (p *parser) between() (bool, error) {
+	from := p.pos
+	if !p.accept("between") {
+		return false, nil
+	}
+	mark := p.pos
+	if a, ok := p.number(); ok && p.accept("and") {
+		if b, ok := p.number(); ok {
+			if f := p.pastUnit(); f != nil {
+				return true, p.setRange(f, a, b, from)
+			}
+		}
+	}
+	p.pos = mark
+	if a, ok, err := p.clock(); ok || err != nil {
+		if err != nil || !p.accept("and") {
+			return false, err
+		}
+		b, ok, err := p.clock()
+		if !ok || err != nil {
+			return false, err
+		}
+		return true, p.window(a, b, from)
+	}
+	if p.accept("the") {
+		a, ok := p.ordinal()
+		if !ok || !p.accept("and") {
+			return false, nil
+		}
+		p.accept("the")
+		b, ok := p.ordinal()
+		if !ok || !p.ofMonth() {
+			return false, nil
+		}
+		return true, p.setRange(&p.dom, a, b, from)
+	}
+	if a, ok := p.monthName(); ok && p.accept("and") {
+		if b, ok := p.monthName(); ok {
+			return true, p.set(&p.month, a+"-"+b, from)
+		}
+	}
+	return false, nil
+}
+
+// from reads "from" followed by a window of times of day, days of the week or months, or by the year steps of years start from
+func (p *parser) from() (bool, error) {
+	from := p.pos
+	if !p.accept("from") {
+		return false, nil
+	}
+	mark := p.pos
+	if a, ok, err := p.clock(); ok || err != nil {
+		if err != nil || !p.through() {
+			return false, err
+		}
+		b, ok, err := p.clock()
+		if !ok || err != nil {
+			return false, err
+		}
+		return true, p.window(a, b, from)
+	}
+	if a, ok := p.weekday(); ok && p.through() {
+		if b, ok := p.weekday(); ok {
+			return true, p.set(&p.dow, a+"-"+b, from)
+		}
+	}
+	p.pos = mark
+	if a, ok := p.monthName(); ok && p.through() {
+		if b, ok := p.monthName(); ok {
+			return true, p.set(&p.month, a+"-"+b, from)
+		}
+	}
+	p.pos = mark
+	if y, ok := p.number(); ok && y >= 1970 {
+		p.quartz = true
+		return true, p.set(&p.year, strconv.Itoa(y), from)
+	}
+	return false, nil
+}
+
+// window sets the fields a window between two times of day stands for. Windows from the start of an hour to the end of another run through
+// both hours, windows within an hour through the minutes between, and windows starting and ending at the same minute of two hours through the
+// hours between, at that minute for "every hour".
+func (p *parser) window(a, b clock, from int) error {
+	switch {
+	case a.s != 0 || b.s != 0 || a.h > b.h || (a.h == b.h && a.m > b.m):
+	case a.m == 0 && b.m == 59:
+		return p.setRange(&p.hour, a.h, b.h, from)
+	case a.h == b.h:
+		if err := p.setRange(&p.minute, a.m, b.m, from); err != nil {
+			return err
+		}
+		return p.set(&p.hour, strconv.Itoa(a.h), from)
+	case a.m == b.m && p.hourly && p.hour.step == 0:
+		for h := a.h; h <= b.h; h++ {
+			p.clocks = append(p.clocks, clock{h: h, m: a.m})
+		}
+		return nil
+	case a.m == b.m:
+		// the minutes of "every 15 minutes between 9am and 5pm" are already said
+		if p.minute.base == "" && p.minute.step == 0 && (a.m != 0 || p.hour.step == 0) {
+			if err := p.set(&p.minute, strconv.Itoa(a.m), from); err != nil {
+				return err
+			}
+		}
+		return p.setRange(&p.hour, a.h, b.h, from)
+	}
+	return p.errorf(ErrUnsupported, from, p.pos, "cron cannot fire only within %q", p.words(from, p.pos))
+}
+
+// setRange sets a field to the values from a to b
+func (p *parser) setRange(f *field, a, b, from int) error {
+	if a == b {
+		return p.set(f, strconv.Itoa(a), from)
+	}
+	return p.set(f, strconv.Itoa(a)+"-"+strconv.Itoa(b), from)
+}
+
+// on reads "on" followed by days of the week or days of the month
+func (p *parser) on() (bool, error) {
+	if !p.accept("on") {
+		return false, nil
+	}
+	return p.days()
+}
+
+// days reads days of the week, such as "Monday through Friday", or days of the month, such as "the 1st of the month", "the last day of the
+// month" or "the first Monday of each month"
+func (p *parser) days() (bool, error) {
+	from := p.pos
+	if days, ok := p.list(p.weekday); ok {
+		return true, p.set(&p.dow, days, from)
+	}
+	p.accept("the")
+	if p.accept("last") {
+		switch {
+		case p.accept("day") && p.ofMonth():
+			return true, p.special(&p.dom, "L", from)
+		case p.accept("weekday") && p.ofMonth():
+			return true, p.special(&p.dom, "LW", from)
+		}
+		if w, ok := p.weekday(); ok && !strings.ContainsAny(w, ",-") && p.ofMonth() {
+			return true, p.special(&p.dow, w+"L", from)
+		}
+		return false, nil
+	}
+	if p.accept("weekday", "nearest") {
+		p.accept("to")
+		p.accept("the")
+		if d, ok := p.ordinal(); ok && p.ofMonth() {
+			return true, p.special(&p.dom, strconv.Itoa(d)+"W", from)
+		}
+		return false, nil
+	}
+	mark := p.pos
+	if n, ok := p.ordinal(); ok {
+		if p.accept("last", "day") && p.ofMonth() {
+			if n == 1 {
+				return true, p.special(&p.dom, "L", from)
+			}
+			return true, p.special(&p.dom, "L-"+strconv.Itoa(n-1), from)
+		}
+		if w, ok := p.weekday(); ok && !strings.ContainsAny(w, ",-") && p.ofMonth() {
+			if n > 5 {
+				return false, p.errorf(ErrUnsupported, from, p.pos, "no month has a %s %s", p.words(mark, mark+1), p.words(mark+1, mark+2))
+			}
+			return true, p.special(&p.dow, w+"#"+strconv.Itoa(n), from)
+		}
+	}
+	p.pos = mark
+	ds, ok := p.list(p.ordinalTerm)
+	if !ok {
+		return false, nil
+	}
+	if p.ofMonth() {
+		return true, p.set(&p.dom, ds, from)
+	}
+	if p.accept("of") {
+		if ms, ok := p.list(p.monthName); ok {
+			if err := p.set(&p.dom, ds, from); err != nil {
+				return false, err
+			}
+			return true, p.set(&p.month, ms, from)
+		}
+		return false, nil
+	}
+	return true, p.set(&p.dom, ds, from)
+}
+
+// special sets a field to a Quartz special term
+func (p *parser) special(f *field, term string, from int) error {
+	p.quartz = true
+	return p.set(f, term, from)
+}
+
+// ofMonth reads "of the month", "of each month" or "of every month"
+func (p *parser) ofMonth() bool {
+	mark := p.pos
+	if p.accept("of") {
+		if _, ok := p.acceptAny("the", "each", "every"); ok && p.accept("month") {
+			return true
+		}
+	}
+	p.pos = mark
+	return false
+}
+
+// in reads "in" followed by months or years
+func (p *parser) in() (bool, error) {
+	from := p.pos
+	if !p.accept("in") {
+		return false, nil
+	}
+	if ms, ok := p.list(p.monthName); ok {
+		return true, p.set(&p.month, ms, from)
+	}
+	if ys, ok := p.list(p.yearNumber); ok {
+		p.quartz = true
+		return true, p.set(&p.year, ys, from)
+	}
+	return false, nil
+}
+
+// during reads "during the" hours written as times of day, followed by "hours"
+func (p *parser) during() (bool, error) {
+	from := p.pos
+	if !p.accept("during", "the") {
+		return false, nil
+	}
+	hours, ok := p.list(func() (string, bool) {
+		c, ok, _ := p.clock()
+		if !ok || c.m != 0 || c.s != 0 {
+			return "", false
+		}
+		return strconv.Itoa(c.h), true
+	})
+	if !ok || !p.accept("hours") {
+		return false, nil
+	}
+	return true, p.set(&p.hour, hours, from)
+}
+
+// ifWeekday reads "if it is a" followed by days of the week the days of the month must fall on
+func (p *parser) ifWeekday() (bool, error) {
+	from := p.pos
+	if !p.accept("if", "it", "is") {
+		return false, nil
+	}
+	p.acceptAny("a", "an")
+	days, ok := p.list(p.weekday)
+	if !ok {
+		return false, nil
+	}
+	p.both = true
+	return true, p.set(&p.dow, days, from)
+}
+
+// list reads a list of terms separated by commas, "and" and "or", writing runs such as "Monday through Friday" as ranges. It returns the list
+// as a cron field.
+func (p *parser) list(item func() (string, bool)) (string, bool) {
+	var terms []string
+	for {
+		mark := p.pos
+		if len(terms) > 0 && !p.separator() {
+			break
+		}
+		first, ok := item()
+		if !ok {
+			p.pos = mark
+			break
+		}
+		term := first
+		rangeMark := p.pos
+		if p.through() {
+			if last, ok := item(); ok {
+				term = first + "-" + last
+			} else {
+				p.pos = rangeMark
+			}
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, ","), len(terms) > 0
+}
+
+// separator reads a comma, "and" or "or" between the terms of a list, along with an "and" or "or" after a comma
+func (p *parser) separator() bool {
+	if p.accept(",") {
+		p.acceptAny("and", "or")
+		return true
+	}
+	_, ok := p.acceptAny("and", "or")
+	return ok
+}
+
+// through reads the word joining the bounds of a range
+func (p *parser) through() bool {
+	_, ok := p.acceptAny("through", "thru", "to", "until", "till", "-")
+	return ok
+}
+
+// number reads a number written in digits
+func (p *parser) number() (int, bool) {
+	w := p.peek()
+	if w == "" || strings.Trim(w, "0123456789") != "" || len(w) > 4 {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(w)
+	p.pos++
+	return n, true
+}
+
+// numeral reads a number as a list term
+func (p *parser) numeral() (string, bool) {
+	n, ok := p.number()
+	return strconv.Itoa(n), ok
+}
+
+// yearNumber reads a year as a list term
+func (p *parser) yearNumber() (string, bool) {
+	mark := p.pos
+	if n, ok := p.number(); ok && n >= 1970 {
+		return strconv.Itoa(n), true
+	}
+	p.pos = mark
+	return "", false
+}
+
+// ordinal reads an ordinal, such as "15th" or "first"
+func (p *parser) ordinal() (int, bool) {
+	w := p.peek()
+	if n, ok := ordinalWords[w]; ok {
+		p.pos++
+		return n, true
+	}
+	m := ordinalPattern.FindStringSubmatch(w)
+	if m == nil {
+		return 0, false
+	}
+	p.pos++
+	n, _ := strconv.Atoi(m[1])
+	return n, true
+}
+
+// ordinalTerm reads a day of the month as a list term, written as an ordinal after an optional "the"
+func (p *parser) ordinalTerm() (string, bool) {
+	mark := p.pos
+	p.accept("the")
+	n, ok := p.ordinal()
+	if !ok || n < 1 || n > 31 {
+		p.pos = mark
+		return "", false
+	}
+	// "the first Monday" names a day of the week, not a day of the month
+	if _, ok := weekdays[p.peek()]; ok {
+		p.pos = mark
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+func (p *parser) weekday() (string, bool) {
+	if term, ok := weekdays[p.peek()]; ok {
+		p.pos++
+		return term, true
+	}
+	return "", false
+}
+
+func (p *parser) monthName() (string, bool) {
+	if term, ok := months[p.peek()]; ok {
+		p.pos++
+		return term, true
+	}
+	return "", false
+}
+
+// clock reads a time of day: "noon", "midnight", a 24-hour time such as "17:30" or "12:00:30", or a 12-hour time such as "9am", "9:30 pm" or
+// "9:30p.m.". Hours written alone, without minutes or "am" or "pm", are not times of day.
+func (p *parser) clock() (clock, bool, error) {
+	from := p.pos
+	switch p.peek() {
+	case "noon", "midday":
+		p.pos++
+		return clock{h: 12}, true, nil
+	case "midnight":
+		p.pos++
+		return clock{}, true, nil
+	}
+	m := clockPattern.FindStringSubmatch(p.peek())
+	if m == nil {
+		return clock{}, false, nil
+	}
+	p.pos++
+	suffix := m[4]
+	if suffix == "" {
+		if w, ok := p.acceptAny("am", "pm"); ok {
+			suffix = w
+		}
+	}
+	if m[2] == "" && suffix == "" {
+		p.pos = from
+		return clock{}, false, nil
+	}
+	c := clock{}
+	c.h, _ = strconv.Atoi(m[1])
+	c.m, _ = strconv.Atoi(m[2])
+	c.s, _ = strconv.Atoi(m[3])
+	switch {
+	case suffix != "" && (c.h < 1 || c.h > 12):
+		return clock{}, false, p.errorf(ErrUnsupported, from, p.pos, "%q is not a time of day", p.words(from, p.pos))
+	case suffix == "am" && c.h == 12:
+		c.h = 0
+	case suffix == "pm" && c.h != 12:
+		c.h += 12
+	}
+	if c.h > 23 || c.m > 59 || c.s > 59 {
+		return clock{}, false, p.errorf(ErrUnsupported, from, p.pos, "%q is not a time of day", p.words(from, p.pos))
+	}
+	return c, true, nil
+}
@@ -0,0 +1,293 @@
+package phrase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dark-enstein/crontable/pkg/meaning"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Sentinel errors classifying why a schedule described in words could not be read. The Diagnostic returned for a problem unwraps to one of them,
+// and points at the words responsible.
+var (
+	ErrUnsupported = errors.New("unsupported phrase")
+	ErrAmbiguous   = errors.New("ambiguous phrase")
+	ErrConflict    = errors.New("conflicting phrases")
+)
+
+// Parse reads a schedule described in controlled English, such as "every weekday at 9:30am" or "first Monday of each month at noon", into the
+// CronExpressionDecoded it describes. See ParseSchedule for the grammar.
+func Parse(text string) (*reader.CronExpressionDecoded, error) {
+	s, err := ParseSchedule(text)
+	if err != nil {
+		return nil, err
+	}
+	return s.CronExpressionDecoded, nil
+}
+
+// ParseSchedule reads a schedule described in controlled English into a Schedule holding the cron expression it stands for. The expression is
+// written in the Vixie dialect, or in the Quartz dialect when the schedule needs seconds, years or a Quartz special term such as the last day of
+// the month.
+//
+// A description is a sequence of phrases, in any order, separated by spaces, commas or "and":
+//
+//	every minute, every 15 minutes, every hour, every other hour, every 2 days, every 3 months, every second, every 10 seconds
+//	at 9:30am, at 17:00, at noon and midnight, at 12:00:30, at 15 minutes past the hour, at 30 seconds past the minute
+//	between 9am and 5pm, from 09:00 to 17:59, between 10 and 40 minutes past the hour, starting at 01:00, during the 09:00 and 17:00 hours
+//	on Monday, on weekdays, every weekend, Monday through Friday, if it is a Monday
+//	on the 1st and 15th of the month, on the 1st of January, on the last day of the month, first Monday of each month, on the last Friday of the month
+//	in January through March, every 3 months, in 2026
+//
+// Windows between two times of day run through the last hour named, so "every 15 minutes between 9am and 5pm" fires until 17:45. Explanations
+// written by meaning.Explain read back into the schedule they explain, as do the nicknames of the reader and their explanations.
+// The error returned is a reader.Diagnostic pointing at the words responsible, classified by ErrUnsupported for phrases outside the grammar or
+// schedules cron cannot express, ErrAmbiguous for phrases that could mean more than one schedule, and ErrConflict for phrases contradicting each other.
+func ParseSchedule(text string) (*reader.Schedule, error) {
+	if macro, ok := nickname(text); ok {
+		return reader.Vixie.Parse(macro)
+	}
+	p := &parser{text: text, toks: tokenize(text)}
+	if len(p.toks) == 0 {
+		return nil, p.errorf(ErrUnsupported, 0, 0, "no schedule described")
+	}
+	for !p.done() {
+		if p.accept(",") || p.accept("and") {
+			continue
+		}
+		ok, err := p.clause()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, p.errorf(ErrUnsupported, p.pos, p.pos+1, "unsupported phrase %q", p.words(p.pos, p.pos+1))
+		}
+	}
+	expr, d, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	s, err := d.Parse(expr)
+	if err != nil {
+		// leave out where in the expression written the reader failed, which the description does not show
+		msg := err.Error()
+		var diag reader.Diagnostic
+		if errors.As(err, &diag) {
+			msg = diag.Message
+		}
+		return nil, p.errorf(ErrUnsupported, 0, len(p.toks), "cron cannot express the schedule described: %s", msg)
+	}
+	if s.Next(time.Date(reader.YearBase, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)).IsZero() {
+		return nil, p.never()
+	}
+	return s, nil
+}
+
+// never returns the error for a description of days that never come, such as "on the 31st of February", pointing at the words naming days,
+// months and years
+func (p *parser) never() error {
+	from, to := len(p.toks), 0
+	for _, f := range []*field{&p.dom, &p.month, &p.dow, &p.year} {
+		if f.words != "" && f.from < from {
+			from = f.from
+		}
+		if f.words != "" && f.to > to {
+			to = f.to
+		}
+	}
+	if to == 0 {
+		from, to = 0, len(p.toks)
+	}
+	return p.errorf(ErrConflict, from, to, "%q never comes, so the schedule would never fire", p.words(from, to))
+}
+
+// nickname returns the nickname a description stands for when it is one, written with or without its "@", or the explanation meaning.Explain
+// gives of one
+func nickname(text string) (string, bool) {
+	text = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(text), "."))
+	if _, ok := reader.Macros["@"+strings.TrimPrefix(text, "@")]; ok {
+		return "@" + strings.TrimPrefix(text, "@"), true
+	}
+	names := make([]string, 0, len(meaning.TextMacro))
+	for name := range meaning.TextMacro {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.ToLower(meaning.TextMacro[name]) == text {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// token is a word of a description, lowercased, along with where it was found
+type token struct {
+	text   string
+	offset int
+	length int
+}
+
+// tokenize splits a description into words. Commas and hyphens are words of their own, periods within words are dropped so that "p.m." reads
+// as "pm", and trailing periods end words.
+func tokenize(text string) []token {
+	var toks []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := strings.ToLower(strings.ReplaceAll(text[start:end], ".", ""))
+		if word != "" {
+			toks = append(toks, token{text: word, offset: start, length: end - start})
+		}
+		start = -1
+	}
+	for i, r := range text {
+		switch {
+		case r == ',' || r == ';' || r == '-':
+			flush(i)
+			sep := ","
+			if r == '-' {
+				sep = "-"
+			}
+			toks = append(toks, token{text: sep, offset: i, length: 1})
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush(i)
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	flush(len(text))
+	return toks
+}
+
+// parser reads the phrases of a description into the fields of a schedule
+type parser struct {
+	text string
+	toks []token
+	pos  int
+
+	second, minute, hour, dom, month, dow, year field
+	// clocks holds the times of day named by "at" phrases and runs of hours
+	clocks []clock
+	// hourly, weekly, monthly and yearly record "every hour", "every week", "every month" and "every year", which leave fields to their defaults
+	hourly, weekly, monthly, yearly bool
+	// quartz records that the schedule needs the Quartz dialect
+	quartz bool
+	// either records that the days of the week were named by "every", firing on them as well as on the days of the month, and both that they
+	// were named by "if it is a", firing on days of the month falling on them
+	either, both bool
+}
+
+// field is a field of the schedule as described: its values as a cron term such as "9-17" or "MON,FRI", its step, and the words that set them,
+// from token from up to token to
+type field struct {
+	base     string
+	step     int
+	words    string
+	from, to int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.toks[p.pos].text
+}
+
+// accept consumes the words passed in when the description continues with them
+func (p *parser) accept(words ...string) bool {
+	for i, w := range words {
+		if p.pos+i >= len(p.toks) || p.toks[p.pos+i].text != w {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+// acceptAny consumes one of the words passed in, returning it
+func (p *parser) acceptAny(words ...string) (string, bool) {
+	for _, w := range words {
+		if p.accept(w) {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+// words returns the description as written from token from up to token to
+func (p *parser) words(from, to int) string {
+	if from >= len(p.toks) || to <= from {
+		return ""
+	}
+	if to > len(p.toks) {
+		to = len(p.toks)
+	}
+	end := p.toks[to-1].offset + p.toks[to-1].length
+	return p.text[p.toks[from].offset:end]
+}
+
+// errorf returns a Diagnostic classified by kind, pointing at the words from token from up to token to
+func (p *parser) errorf(kind error, from, to int, format string, a ...interface{}) error {
+	offset, length := len(p.text), 0
+	if from < len(p.toks) {
+		offset = p.toks[from].offset
+		length = len(p.words(from, to))
+	}
+	return reader.Diagnostic{
+		Offset: offset, Length: length, Column: utf8.RuneCountInString(p.text[:offset]) + 1, Token: p.text[offset : offset+length],
+		Severity: reader.SeverityError, Message: fmt.Sprintf(format, a...), Kind: kind,
+	}
+}
+
+// set sets the values of a field to the cron term base, described by the words from token from, erroring when other words set it otherwise
+func (p *parser) set(f *field, base string, from int) error {
+	// "every minute between 10 and 20 minutes past the hour" narrows every minute down to those between
+	if f.base == "*" && f.step == 0 {
+		f.base = ""
+	}
+	if base == "*" && f.base != "" {
+		return nil
+	}
+	if f.base != "" && f.base != base {
+		return p.errorf(ErrConflict, from, p.pos, "%q contradicts %q", p.words(from, p.pos), f.words)
+	}
+	f.base, f.words, f.from, f.to = base, p.words(from, p.pos), from, p.pos
+	return nil
+}
+
+// setStep sets the step of a field, described by the words from token from, erroring when other words set it otherwise
+func (p *parser) setStep(f *field, step, from int) error {
+	if f.step != 0 && f.step != step {
+		return p.errorf(ErrConflict, from, p.pos, "%q contradicts %q", p.words(from, p.pos), f.words)
+	}
+	f.step, f.words, f.from, f.to = step, p.words(from, p.pos), from, p.pos
+	return nil
+}
+
+// clause reads one phrase, reporting false without consuming anything when the description does not continue with a phrase of the grammar
+func (p *parser) clause() (bool, error) {
+	for _, read := range []func() (bool, error){p.every, p.nicknameWord, p.at, p.starting, p.between, p.from, p.on, p.in, p.during, p.ifWeekday, p.days, p.times} {
+		mark := p.pos
+		ok, err := read()
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+		p.pos = mark
+	}
+	return false, nil
+}
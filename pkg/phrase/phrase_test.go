@@ -0,0 +1,108 @@
+package phrase
+
+import (
+	"errors"
+	"github.com/dark-enstein/crontable/pkg/meaning"
+	"github.com/dark-enstein/crontable/pkg/reader"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Phrases struct {
+	suite.Suite
+}
+
+// TestParse tests that descriptions read into the expressions they describe
+func (c *Phrases) TestParse() {
+	for text, expected := range map[string]string{
+		"every weekday at 9:30am":                         "30 9 * * MON-FRI",
+		"first Monday of each month at noon":              "0 0 12 ? * MON#1",
+		"every 15 minutes":                                "*/15 * * * *",
+		"every 5 minutes between 9am and 5pm on weekdays": "*/5 9-17 * * MON-FRI",
+		"at 9am and 5pm on weekends":                      "0 9,17 * * SAT,SUN",
+		"every other hour":                                "0 */2 * * *",
+		"on the last day of the month at 23:00":           "0 0 23 L * ?",
+		"every 2 days if it is a Monday, at noon":         "0 12 */2 * MON",
+		"at 6:45 p.m. on Mondays, Wednesdays and Fridays": "45 18 * * MON,WED,FRI",
+		"at midnight on the 1st and 15th of every month":  "0 0 1,15 * *",
+		"every hour from 9am to 5pm, Monday to Friday":    "0 9-17 * * MON-FRI",
+		"every 10 seconds":                                "*/10 * * * * ?",
+		"at 08:00 on the 1st of January through March":    "0 8 1 JAN-MAR *",
+		"weekly at 7am":                                   "0 7 * * SUN",
+		"daily":                                           "@daily",
+		"Every day at midnight.":                          "@daily",
+	} {
+		s, err := ParseSchedule(text)
+		if c.Assert().NoError(err, text) {
+			c.Assert().Equal(expected, s.Expr, text)
+		}
+	}
+}
+
+// TestParseErrors tests that ambiguous, contradictory and unsupported descriptions are reported, pointing at the words responsible
+func (c *Phrases) TestParseErrors() {
+	for _, tc := range []struct {
+		text  string
+		kind  error
+		token string
+	}{
+		{"at 7 every day", ErrAmbiguous, "7"},
+		{"every weekday", ErrAmbiguous, "every weekday"},
+		{"every 15 minutes and every 5 minutes", ErrConflict, "every 5 minutes"},
+		{"every fortnight at noon", ErrUnsupported, "every"},
+		{"at 9am on the 1st of the month on Monday", ErrUnsupported, "at 9am on the 1st of the month on Monday"},
+		{"at 9:15 and 17:30", ErrUnsupported, "at 9:15 and 17:30"},
+		{"at 13pm", ErrUnsupported, "13pm"},
+		{"every 90 minutes", ErrUnsupported, "every 90 minutes"},
+		{"every 25 hours on weekdays", ErrUnsupported, "every 25 hours"},
+		{"at noon on the 31st of February", ErrConflict, "the 31st of February"},
+		{"at noon on the 30th in February", ErrConflict, "the 30th in February"},
+		{"", ErrUnsupported, ""},
+	} {
+		_, err := ParseSchedule(tc.text)
+		c.Require().Error(err, tc.text)
+		c.Assert().ErrorIs(err, tc.kind, tc.text)
+		var diag reader.Diagnostic
+		if c.Assert().True(errors.As(err, &diag), tc.text) {
+			c.Assert().Equal(tc.token, diag.Token, tc.text)
+		}
+	}
+}
+
+// TestRoundTrip tests that the explanation of every expression of the golden files of the meaning package reads back into a schedule explained
+// the same way and firing at the same times
+func (c *Phrases) TestRoundTrip() {
+	from := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"vixie", "quartz"} {
+		d, err := reader.LookupDialect(name)
+		c.Require().NoError(err)
+		file := filepath.Join("..", "meaning", "testdata", name+".golden")
+		text, err := os.ReadFile(file)
+		c.Require().NoError(err)
+		for i, line := range strings.Split(strings.TrimSuffix(string(text), "\n"), "\n") {
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+			expr, explanation, _ := strings.Cut(line, "\t")
+			orig, err := d.Parse(expr)
+			c.Require().NoError(err, "%s:%d", file, i+1)
+
+			parsed, err := ParseSchedule(explanation)
+			if !c.Assert().NoError(err, "%s:%d: %s", file, i+1, explanation) {
+				continue
+			}
+			c.Assert().Equal(explanation, string(meaning.ExplainSchedule(parsed)), "%s:%d: %s read as %s", file, i+1, expr, parsed.Expr)
+			if orig.Trigger == reader.TriggerTime {
+				c.Assert().Equal(orig.NextN(from, 20), parsed.NextN(from, 20), "%s:%d: %s read as %s", file, i+1, expr, parsed.Expr)
+			}
+		}
+	}
+}
+
+func TestPhrases(t *testing.T) {
+	suite.Run(t, new(Phrases))
+}
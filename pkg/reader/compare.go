@@ -0,0 +1,65 @@
+package reader
+
+import (
+	"time"
+)
+
+// How many years of days Subsumes compares, counted from YearBase. The days a schedule fires on repeat with the 400 year cycle of the Gregorian
+// calendar, which also spans every year the dialects accept. Without years or Quartz special terms, they only depend on the month, day and
+// weekday of each day, and every combination of those occurs within 28 years.
+const (
+	cycleYears      = 400
+	plainCycleYears = 28
+)
+
+// Equivalent reports whether two schedules fire at exactly the same times, whatever their dialects: "*/15 * * * *" and "0,15,30,45 * * * *" are
+// equivalent, as are the Vixie "0 0 * * SUN" and "0 0 * * 7" and the Quartz "0 0 0 ? * 1". See Subsumes.
+func Equivalent(a, b *Schedule) bool {
+	ca, cb := a.Compile(), b.Compile()
+	return ca.Subsumes(cb) && cb.Subsumes(ca)
+}
+
+// Subsumes reports whether schedule a fires at every time schedule b fires at. It compares the values the fields of the schedules expand to,
+// and the days each fires on as its dialect combines the day fields, working out the days of Quartz special terms from the calendar.
+// Times are compared by the wall clock of the time zones the schedules fire in, leaving daylight saving transitions aside; schedules firing
+// in different time zones never subsume one another. A schedule that never fires, such as one for February 30th, is subsumed by any other,
+// while @reboot only subsumes and is subsumed by @reboot.
+func Subsumes(a, b *Schedule) bool {
+	return a.Compile().Subsumes(b.Compile())
+}
+
+// Subsumes reports whether the compiled schedule fires at every time the compiled schedule o fires at. See the Subsumes function.
+func (c *Compiled) Subsumes(o *Compiled) bool {
+	if c.Reboot || o.Reboot {
+		return c.Reboot && o.Reboot
+	}
+	if !sameLocation(c.Location, o.Location) {
+		return false
+	}
+	// a schedule fires at every time of day its clock fields allow on every day it fires, so times of day subsume field by field
+	clock := o.Second&^c.Second == 0 && o.Minute&^c.Minute == 0 && o.Hour&^c.Hour == 0
+	years := cycleYears
+	if c.plain() && o.plain() {
+		years = plainCycleYears
+	}
+	end := time.Date(YearBase+years, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for day := time.Date(YearBase, time.January, 1, 0, 0, 0, 0, time.UTC); day.Before(end); day = day.Add(24 * time.Hour) {
+		if o.matchesDay(day) && (!clock || !c.matchesDay(day)) {
+			return false
+		}
+	}
+	return true
+}
+
+// plain reports whether the days the compiled schedule fires on depend on nothing but their month, day and weekday
+func (c *Compiled) plain() bool {
+	return c.Years == nil && len(c.DayOfMonthSpecials) == 0 && len(c.DayOfWeekSpecials) == 0
+}
+
+// sameLocation reports whether two time zones are the same, nil standing for the zone of the times searched from
+func sameLocation(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
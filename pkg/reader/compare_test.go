@@ -0,0 +1,68 @@
+package reader
+
+import (
+	"time"
+)
+
+// compareCase is a pair of expressions, along with whether the first fires at every time the second does, and the other way around
+type compareCase struct {
+	dialectA *Dialect
+	a        string
+	dialectB *Dialect
+	b        string
+	subsumes bool
+	subsumed bool
+}
+
+var CompareTestInputs = []compareCase{
+	{Vixie, "*/15 * * * *", Vixie, "0,15,30,45 * * * *", true, true},
+	{Vixie, "0 0 * * SUN", Vixie, "0 0 * * 7", true, true},
+	{Vixie, "0 0 * * 0", Quartz, "0 0 0 ? * 1", true, true},
+	{Vixie, "0 9 * * 1-5", Vixie, "0 9 * * MON-FRI", true, true},
+	{Vixie, "@monthly", Vixie, "0 0 1 * *", true, true},
+	{Vixie, "0 0 * * 0-6", Vixie, "0 0 * * *", true, true},
+	{Vixie, "*/5 * * * *", Vixie, "*/15 * * * *", true, false},
+	{Vixie, "0 9-17 * * *", Vixie, "0 9 * * 1-5", true, false},
+	{Vixie, "0 0 1 * 0-6", Vixie, "0 0 1 * *", true, false},
+	{Vixie, "0 0 1,15 * *", Vixie, "0 0 1 * 1", false, false},
+	{Vixie, "0 9 * * *", Vixie, "0 0 31 2 *", true, false},
+	{Vixie, "0 0 30 2 *", Vixie, "0 0 31 2 *", true, true},
+	{Quartz, "0 0 12 28-31 * ?", Quartz, "0 0 12 L * ?", true, false},
+	{Quartz, "0 0 12 * * ?", Quartz, "0 0 12 * * ? 2026", true, false},
+	{Quartz, "0 0 12 ? * 6L", Quartz, "0 0 12 ? * 6#5", true, false},
+	{Quartz, "30 0 9 * * ?", Vixie, "0 9 * * *", false, false},
+	{Vixie, "@reboot", Cronie, "@reboot", true, true},
+	{Vixie, "@reboot", Vixie, "@daily", false, false},
+}
+
+// TestSubsumes tests which schedules fire at every time others do, and that the run times of a subsumed schedule all match the schedule subsuming it
+func (c *CronTab) TestSubsumes() {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	for _, tc := range CompareTestInputs {
+		a, err := tc.dialectA.Parse(tc.a)
+		c.Require().NoError(err, tc.a)
+		b, err := tc.dialectB.Parse(tc.b)
+		c.Require().NoError(err, tc.b)
+		c.Assert().Equal(tc.subsumes, Subsumes(a, b), "%s subsumes %s", tc.a, tc.b)
+		c.Assert().Equal(tc.subsumed, Subsumes(b, a), "%s subsumes %s", tc.b, tc.a)
+		c.Assert().Equal(tc.subsumes && tc.subsumed, Equivalent(a, b), "%s is equivalent to %s", tc.a, tc.b)
+		if tc.subsumes {
+			for _, t := range b.NextN(from, 50) {
+				c.Assert().True(a.Matches(t), "%s at %s", tc.a, t)
+			}
+		}
+	}
+}
+
+// TestSubsumesLocation tests that schedules firing in different time zones do not subsume one another
+func (c *CronTab) TestSubsumesLocation() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Require().NoError(err)
+	a, err := ParseInLocation("0 9 * * *", berlin)
+	c.Require().NoError(err)
+	b, err := ParseInLocation("0 9 * * *", time.UTC)
+	c.Require().NoError(err)
+	c.Assert().False(Equivalent(a, b))
+	b.Location = berlin
+	c.Assert().True(Equivalent(a, b))
+}
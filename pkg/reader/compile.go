@@ -0,0 +1,263 @@
+package reader
+
+import (
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// YearBase is the year bit 0 of Compiled.Years stands for
+const YearBase = 1970
+
+// Compiled is a schedule compiled into one bit set per field, for matching and searching times without interpreting delimiters: bit n of a field
+// is set when the schedule fires at value n of it, so Minute uses 60 bits, Hour 24, DayOfMonth bits 1-31 and Month bits 1-12. Days of the week
+// count from Sunday as 0 whatever the dialect counts them from. A Compiled is not changed by matching or searching, and may be shared between goroutines.
+type Compiled struct {
+	Second     uint64
+	Minute     uint64
+	Hour       uint64
+	DayOfMonth uint64
+	Month      uint64
+	DayOfWeek  uint64
+	// Years holds a bit per year counted from YearBase; it is nil when the schedule leaves the year unrestricted
+	Years []uint64
+	// DayOfMonthSpecials and DayOfWeekSpecials hold the Quartz special terms of the day fields, whose days depend on the calendar.
+	// Days of the week in DayOfWeekSpecials count from Sunday as 0.
+	DayOfMonthSpecials []Span
+	DayOfWeekSpecials  []Span
+	// Either is set when a day matching either day field is enough, as under Vixie's rule for schedules restricting both
+	Either bool
+	// Seconds is set for schedules with a seconds field; the others fire at second 0 of each minute they match
+	Seconds bool
+	// Wildcard is set for schedules with "*" in their hour or minute field, which Vixie cron runs by the new time across daylight saving transitions
+	Wildcard bool
+	// Reboot is set for @reboot, which matches no time
+	Reboot bool
+	// Location is the time zone the schedule fires in; when nil, times are matched and searched in their own location
+	Location *time.Location
+}
+
+// Compile compiles the schedule into a bit set per field. The Compiled returned does not follow later changes to the schedule.
+func (s *Schedule) Compile() *Compiled {
+	c := &Compiled{Location: s.Location, Reboot: s.Trigger == TriggerReboot}
+	if c.Reboot {
+		return c
+	}
+	d := s.Dialect
+	if d == nil {
+		d = Vixie
+	}
+	sunday := 0
+	if spec, ok := d.Spec(DayOfTheWeek); ok {
+		sunday = spec.Aliases["SUN"]
+	}
+
+	c.Second = 1
+	if s.Second != nil {
+		c.Second, c.Seconds = bitsOf(s.Second.Values(), 0), true
+	}
+	c.Minute = bitsOf(s.Minute.Values(), 0)
+	c.Hour = bitsOf(s.Hour.Values(), 0)
+	c.Month = bitsOf(s.Month.Values(), 0)
+	c.DayOfMonth, c.DayOfMonthSpecials = dayBits(&s.DayOfMonth, 1, 31, 0)
+	c.DayOfWeek, c.DayOfWeekSpecials = dayBits(&s.DayOfWeek, 0, 6, sunday)
+	if s.Year != nil && s.Year.DelimKind != DelimAny && s.Year.Raw != "*" {
+		for _, y := range s.Year.Values() {
+			i := y - YearBase
+			for len(c.Years) <= i/64 {
+				c.Years = append(c.Years, 0)
+			}
+			c.Years[i/64] |= 1 << (i % 64)
+		}
+	}
+	c.Either = d.Days == DaysEither && s.DayOfMonth.Restricted() && s.DayOfWeek.Restricted()
+	c.Wildcard = strings.HasPrefix(s.Hour.Raw, "*") || strings.HasPrefix(s.Minute.Raw, "*")
+	return c
+}
+
+// bitsOf sets the bit of each value, less shift
+func bitsOf(vals []int, shift int) uint64 {
+	var set uint64
+	for _, v := range vals {
+		set |= 1 << (v - shift)
+	}
+	return set
+}
+
+// dayBits compiles a day field into the bits of its values, counted from the value that stands for low less shift, and the special terms it holds.
+// The "?" placeholder sets every bit from low to high, leaving the other day field to decide.
+func dayBits(c *Catcher, low, high, shift int) (uint64, []Span) {
+	if c.DelimKind == DelimAny {
+		return (1<<(high+1) - 1) &^ (1<<low - 1), nil
+	}
+	var specials []Span
+	for _, span := range c.Spans {
+		if isSpecial(span.Kind) {
+			span.Low -= shift
+			span.High -= shift
+			specials = append(specials, span)
+		}
+	}
+	return bitsOf(c.Values(), shift), specials
+}
+
+// Restricted reports whether a day field restricts the days a schedule fires on by Vixie's rule: fields starting with "*", and the "?" placeholder,
+// do not. Fields built or changed since read start with "*" where their first term is a wildcard.
+func (c *Catcher) Restricted() bool {
+	if c.unchanged() {
+		return c.DelimKind != DelimAny && !strings.HasPrefix(c.Raw, "*")
+	}
+	first := c.Terms(0, 0)[0]
+	return first.Kind != DelimAny && first.Kind != DelimWildcard
+}
+
+// Matches reports whether the schedule fires in the minute t falls in, or in the second for schedules with a seconds field, going by the wall clock of
+// the schedule's Location. Matching does not allocate.
+func (c *Compiled) Matches(t time.Time) bool {
+	if c.Reboot {
+		return false
+	}
+	if c.Location != nil {
+		t = t.In(c.Location)
+	}
+	if c.Seconds && !has(c.Second, t.Second()) {
+		return false
+	}
+	return has(c.Minute, t.Minute()) && has(c.Hour, t.Hour()) && c.matchesDay(t)
+}
+
+// Matches reports whether the schedule fires in the minute t falls in. See Compiled.Matches, which avoids compiling the schedule on every call.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.Compile().Matches(t)
+}
+
+// has reports whether the bit of value v is set
+func has(set uint64, v int) bool {
+	return v >= 0 && v < 64 && set&(1<<v) != 0
+}
+
+// hasYear reports whether the schedule fires in the year passed in
+func (c *Compiled) hasYear(year int) bool {
+	if c.Years == nil {
+		return true
+	}
+	i := year - YearBase
+	return i >= 0 && i/64 < len(c.Years) && c.Years[i/64]&(1<<(i%64)) != 0
+}
+
+// matchesDay reports whether the schedule fires on the day t falls in, combining the day fields as its dialect does
+func (c *Compiled) matchesDay(t time.Time) bool {
+	if !has(c.Month, int(t.Month())) || !c.hasYear(t.Year()) {
+		return false
+	}
+	dom := c.matchesDayOfMonth(t)
+	dow := c.matchesDayOfWeek(t)
+	if c.Either {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// matchesDayOfMonth reports whether the day t falls in matches the day of month field, working out the days of the Quartz special terms from the calendar
+func (c *Compiled) matchesDayOfMonth(t time.Time) bool {
+	if has(c.DayOfMonth, t.Day()) {
+		return true
+	}
+	if len(c.DayOfMonthSpecials) == 0 {
+		return false
+	}
+	last := lastDay(t)
+	for _, span := range c.DayOfMonthSpecials {
+		switch span.Kind {
+		case DelimLast:
+			if t.Day() == last-span.Offset {
+				return true
+			}
+		case DelimLastWeekday:
+			if t.Day() == nearestWeekday(t, last) {
+				return true
+			}
+		case DelimWeekday:
+			target := span.Low
+			if target > last {
+				target = last
+			}
+			if t.Day() == nearestWeekday(t, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesDayOfWeek reports whether the day t falls in matches the day of week field, working out the days of the Quartz special terms from the calendar
+func (c *Compiled) matchesDayOfWeek(t time.Time) bool {
+	weekday := int(t.Weekday())
+	if has(c.DayOfWeek, weekday) {
+		return true
+	}
+	for _, span := range c.DayOfWeekSpecials {
+		switch span.Kind {
+		case DelimLast:
+			if weekday == span.Low && t.Day()+7 > lastDay(t) {
+				return true
+			}
+		case DelimNth:
+			if weekday == span.Low && (t.Day()-1)/7+1 == span.Nth {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastDay returns the number of days in the month t falls in, counting February 29 in leap years
+func lastDay(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday nearest to the target day of the month t falls in, without crossing into another month, as the Quartz "W" term does
+func nearestWeekday(t time.Time, target int) int {
+	last := lastDay(t)
+	switch time.Date(t.Year(), t.Month(), target, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if target == 1 {
+			return target + 2
+		}
+		return target - 1
+	case time.Sunday:
+		if target == last {
+			return target - 2
+		}
+		return target + 1
+	}
+	return target
+}
+
+// nextBit returns the lowest value not below v whose bit is set, or -1 when there is none
+func nextBit(set uint64, v int) int {
+	if v < 0 {
+		v = 0
+	}
+	if v > 63 {
+		return -1
+	}
+	if rest := set & (^uint64(0) << v); rest != 0 {
+		return bits.TrailingZeros64(rest)
+	}
+	return -1
+}
+
+// prevBit returns the highest value not above v whose bit is set, or -1 when there is none
+func prevBit(set uint64, v int) int {
+	if v < 0 {
+		return -1
+	}
+	if v > 63 {
+		v = 63
+	}
+	if rest := set & (^uint64(0) >> (63 - v)); rest != 0 {
+		return 63 - bits.LeadingZeros64(rest)
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package reader
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCompile tests that every field compiles into the bits of its values, with days of the week counted from Sunday as 0 whatever the dialect
+func (c *CronTab) TestCompile() {
+	sched, err := Parse("*/15 9-17 1,15 JAN-MAR 5-7")
+	c.Require().NoError(err)
+	comp := sched.Compile()
+	c.Assert().Equal(uint64(1<<0|1<<15|1<<30|1<<45), comp.Minute)
+	c.Assert().Equal(uint64(0x3fe00), comp.Hour)
+	c.Assert().Equal(uint64(1<<1|1<<15), comp.DayOfMonth)
+	c.Assert().Equal(uint64(0xe), comp.Month)
+	c.Assert().Equal(uint64(1<<0|1<<5|1<<6), comp.DayOfWeek)
+	c.Assert().Equal(uint64(1), comp.Second)
+	c.Assert().True(comp.Either)
+	c.Assert().False(comp.Seconds)
+
+	sched, err = Quartz.Parse("0 0 12 ? * MON#2 2026")
+	c.Require().NoError(err)
+	comp = sched.Compile()
+	c.Assert().Equal(uint64(0xfffffffe), comp.DayOfMonth, "? leaves every day to the other field")
+	c.Assert().Equal([]Span{{Low: 1, High: 1, Nth: 2, Kind: DelimNth}}, comp.DayOfWeekSpecials)
+	c.Assert().True(comp.hasYear(2026))
+	c.Assert().False(comp.hasYear(2027))
+	c.Assert().True(comp.Seconds)
+}
+
+// TestRestricted tests that day fields starting with "*", and the "?" placeholder, leave the days unrestricted, whether read or built
+func (c *CronTab) TestRestricted() {
+	dec, err := Vixie.Decode("0 0 */2 * 1")
+	c.Require().NoError(err)
+	c.Assert().False(dec.DayOfMonth.Restricted())
+	c.Assert().True(dec.DayOfWeek.Restricted())
+	dec.DayOfMonth.Spans = []Span{{Low: 1, High: 15, Kind: DelimRange}}
+	c.Assert().True(dec.DayOfMonth.Restricted(), "changed terms")
+
+	quartz, err := Quartz.Decode("0 0 0 ? * 1")
+	c.Require().NoError(err)
+	c.Assert().False(quartz.DayOfMonth.Restricted())
+	c.Assert().False((&Catcher{}).Restricted(), "zero value")
+	c.Assert().False((&Catcher{Low: 2, High: []int{2}, DelimKind: DelimEvery}).Restricted())
+	c.Assert().True((&Catcher{Low: 1, High: []int{5}, DelimKind: DelimRange}).Restricted())
+}
+
+// TestMatches tests that Matches agrees with the days and times of day the schedule fires at
+func (c *CronTab) TestMatches() {
+	sched, err := Parse("30 9 1 * MON")
+	c.Require().NoError(err)
+	comp := sched.Compile()
+	c.Assert().True(comp.Matches(time.Date(2026, 10, 1, 9, 30, 45, 0, time.UTC)), "the first of the month")
+	c.Assert().True(comp.Matches(time.Date(2026, 10, 5, 9, 30, 0, 0, time.UTC)), "a Monday")
+	c.Assert().False(comp.Matches(time.Date(2026, 10, 6, 9, 30, 0, 0, time.UTC)))
+	c.Assert().False(comp.Matches(time.Date(2026, 10, 5, 9, 31, 0, 0, time.UTC)))
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	c.Require().NoError(err)
+	sched, err = ParseInLocation("0 9 * * *", tokyo)
+	c.Require().NoError(err)
+	c.Assert().True(sched.Matches(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)), "09:00 in Tokyo")
+
+	sched, err = Parse("@reboot")
+	c.Require().NoError(err)
+	c.Assert().False(sched.Matches(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)))
+}
+
+// TestNextAgreesWithMatches tests that the field skipping search of Next finds the same times as stepping minute by minute through Matches
+func (c *CronTab) TestNextAgreesWithMatches() {
+	exprs := []string{"*/7 */5 * * *", "0 0 29 2 *", "5 4 1-7 * SUN", "59 23 31 */2 *", "0 12 */3 * 1-5", "@weekly"}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, expr := range exprs {
+		sched, err := Parse(expr)
+		c.Require().NoError(err, expr)
+		comp := sched.Compile()
+		t := from
+		for i := 0; i < 20; i++ {
+			expected := t.Add(time.Minute)
+			for !comp.Matches(expected) {
+				expected = expected.Add(time.Minute)
+			}
+			t = comp.Next(t)
+			c.Require().Equal(expected, t, expr)
+		}
+	}
+}
+
+// TestMatchesAllocs tests that matching a compiled schedule does not allocate
+func (c *CronTab) TestMatchesAllocs() {
+	sched, err := Parse("*/15 9-17 1,15 * MON-FRI")
+	c.Require().NoError(err)
+	comp := sched.Compile()
+	t := time.Date(2026, 10, 15, 9, 45, 0, 0, time.UTC)
+	c.Assert().Zero(testing.AllocsPerRun(100, func() { comp.Matches(t) }))
+	c.Assert().Zero(testing.AllocsPerRun(100, func() { comp.Next(t) }))
+}
+
+func BenchmarkMatches(b *testing.B) {
+	sched, err := Parse("*/15 9-17 1,15 * MON-FRI")
+	if err != nil {
+		b.Fatal(err)
+	}
+	comp := sched.Compile()
+	t := time.Date(2026, 10, 15, 9, 45, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		comp.Matches(t)
+	}
+}
+
+func BenchmarkNext(b *testing.B) {
+	sched, err := Parse("0 0 29 2 MON")
+	if err != nil {
+		b.Fatal(err)
+	}
+	comp := sched.Compile()
+	t := time.Date(2026, 10, 15, 9, 45, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		comp.Next(t)
+	}
+}
+
+func BenchmarkNextSparse(b *testing.B) {
+	sched, err := Parse("0 0 29 2 *")
+	if err != nil {
+		b.Fatal(err)
+	}
+	comp := sched.Compile()
+	t := time.Date(2026, 10, 15, 9, 45, 0, 0, time.UTC)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		comp.Next(t)
+	}
+}
@@ -0,0 +1,231 @@
+package reader
+
+import (
+	"sort"
+	"strings"
+)
+
+// Convert rewrites the schedule as an expression of the dialect d that fires at the same times. Fields are kept as written wherever d reads them the same way,
+// and are otherwise rewritten from the values they match: days of the week are renumbered, "?" and "*" are swapped between the day fields as d requires,
+// and seconds and years are added or dropped. It errors with ErrUnconvertible when d cannot express the schedule, such as a Vixie schedule restricting
+// both day fields converted for Quartz, or a Quartz special term converted for Vixie cron.
+func (d *Dialect) Convert(s *Schedule) (string, error) {
+	src := s.Dialect
+	if src == nil {
+		src = Vixie
+	}
+	if s.Trigger == TriggerReboot {
+		if _, ok := d.Macros["@reboot"]; !ok {
+			return "", errorf(ErrUnconvertible, "the %s dialect has no @reboot", d.Name)
+		}
+		return "@reboot", nil
+	}
+	if expanded, ok := d.Macros[s.Macro]; ok && s.Macro != "" && expanded == src.Macros[s.Macro] {
+		return s.Macro, nil
+	}
+
+	dom, dow, err := d.convertDays(s, src)
+	if err != nil {
+		return "", err
+	}
+	var fields []string
+	for i, spec := range d.Fields {
+		var token string
+		switch spec.Name {
+		case Second:
+			token = "0"
+			if s.Second != nil {
+				token, err = convertField(s.Second, spec)
+			}
+		case Minute:
+			token, err = convertField(&s.Minute, spec)
+		case Hour:
+			token, err = convertField(&s.Hour, spec)
+		case Month:
+			token, err = convertField(&s.Month, spec)
+		case DayOfTheMonth:
+			token = dom
+		case DayOfTheWeek:
+			token = dow
+		case Year:
+			switch {
+			case s.Year != nil:
+				token, err = convertField(s.Year, spec)
+			case i >= len(d.Fields)-d.Optional:
+				continue
+			default:
+				token = "*"
+			}
+		}
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, token)
+	}
+
+	if s.Second != nil {
+		if _, ok := d.Spec(Second); !ok && !sameValues(s.Second.Values(), []int{0}) {
+			return "", errorf(ErrUnconvertible, "the %s dialect has no seconds field for %q", d.Name, s.Second.Raw)
+		}
+	}
+	if s.Year != nil {
+		if _, ok := d.Spec(Year); !ok && s.Year.Raw != "*" {
+			return "", errorf(ErrUnconvertible, "the %s dialect has no year field for %q", d.Name, s.Year.Raw)
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// convertDays rewrites the day fields of the schedule for the dialect d, following how each dialect combines them
+func (d *Dialect) convertDays(s *Schedule, src *Dialect) (string, string, error) {
+	domSpec, _ := d.Spec(DayOfTheMonth)
+	dowSpec, _ := d.Spec(DayOfTheWeek)
+	// a day field covering every day leaves the other to decide, or makes the schedule fire every day where the source dialect fires on days matching either
+	domAll, dowAll := everyDay(&s.DayOfMonth, 31), everyDay(&s.DayOfWeek, 7)
+	either := src.Days == DaysEither && s.DayOfMonth.Restricted() && s.DayOfWeek.Restricted()
+	if either && (domAll || dowAll) {
+		domAll, dowAll = true, true
+	}
+	switch {
+	case !domAll && !dowAll && d.Days == DaysExclusive:
+		return "", "", errorf(ErrUnconvertible, "the %s dialect cannot restrict both day fields, as %q and %q do", d.Name, s.DayOfMonth.Raw, s.DayOfWeek.Raw)
+	case either && !domAll && d.Days != DaysEither:
+		return "", "", errorf(ErrUnconvertible, "the %s dialect combines restricted day fields differently than the %s dialect", d.Name, src.Name)
+	}
+
+	dom, dow := "*", "*"
+	if d.Days == DaysExclusive {
+		if dowAll {
+			dow = "?"
+		} else {
+			dom = "?"
+		}
+	}
+	var err error
+	if !domAll {
+		if dom, err = convertField(&s.DayOfMonth, domSpec); err != nil {
+			return "", "", err
+		}
+	}
+	if !dowAll {
+		if dow, err = convertWeekdays(&s.DayOfWeek, src, dowSpec); err != nil {
+			return "", "", err
+		}
+	}
+	if d.Days == DaysEither && !either && !domAll && !dowAll && !strings.HasPrefix(dom, "*") && !strings.HasPrefix(dow, "*") {
+		return "", "", errorf(ErrUnconvertible, "the %s dialect combines restricted day fields differently than the %s dialect", d.Name, src.Name)
+	}
+	return dom, dow, nil
+}
+
+// everyDay reports whether a day field matches every one of its n values, or is the "?" placeholder
+func everyDay(c *Catcher, n int) bool {
+	return c.DelimKind == DelimAny || (len(specialsOf(c)) == 0 && len(c.Values()) == n)
+}
+
+// convertField rewrites a field for the FieldSpec of another dialect: as written when the spec reads it the same way, and otherwise from the values it matches
+func convertField(c *Catcher, spec FieldSpec) (string, error) {
+	if converted, err := parseField(c.Raw, spec); err == nil && sameValues(converted.Values(), c.Values()) &&
+		sameSpans(specialsOf(&converted), specialsOf(c)) {
+		return c.Raw, nil
+	}
+	if len(specialsOf(c)) > 0 {
+		return "", errorf(ErrUnconvertible, "%s: %q has no equivalent in the target dialect", spec.Name, c.Raw)
+	}
+	vals := c.Values()
+	for _, v := range vals {
+		if v < spec.Low || v > spec.High {
+			return "", errorf(ErrUnconvertible, "%s: %d is out of the target dialect's bounds %d-%d", spec.Name, v, spec.Low, spec.High)
+		}
+	}
+	if len(vals) == spec.High-spec.Low+1 {
+		return "*", nil
+	}
+	return FormatValues(vals), nil
+}
+
+// convertWeekdays rewrites a day of week field for the FieldSpec of another dialect, renumbering its days when the dialects count them from different values
+func convertWeekdays(c *Catcher, src *Dialect, spec FieldSpec) (string, error) {
+	srcSpec, _ := src.Spec(DayOfTheWeek)
+	shift := spec.Aliases["SUN"] - srcSpec.Aliases["SUN"]
+
+	days := map[int]bool{}
+	for _, v := range c.Values() {
+		days[v+shift] = true
+	}
+	var specials []Span
+	for _, span := range specialsOf(c) {
+		span.Low, span.High = span.Low+shift, span.High+shift
+		specials = append(specials, span)
+	}
+
+	if converted, err := parseField(c.Raw, spec); err == nil && sameValues(converted.Values(), keysOf(days)) && sameSpans(specialsOf(&converted), specials) {
+		return c.Raw, nil
+	}
+	if len(specials) > 0 && spec.Specials&SpecialsDayOfWeek == 0 {
+		return "", errorf(ErrUnconvertible, "%s: %q has no equivalent in the target dialect", spec.Name, c.Raw)
+	}
+
+	// fold Sunday into the numbering of the target dialect
+	vals := keysOf(days)
+	for i := range vals {
+		if vals[i] > spec.High {
+			vals[i] -= 7
+		}
+		if vals[i] < spec.Low {
+			vals[i] += 7
+		}
+	}
+	sort.Ints(vals)
+	if len(vals) == 7 {
+		return formatSpans(append([]Span{{Kind: DelimWildcard}}, specials...), spec, false), nil
+	}
+	return FormatValues(vals, specials...), nil
+}
+
+// specialsOf returns the Quartz special terms among the terms of a field
+func specialsOf(c *Catcher) []Span {
+	var specials []Span
+	for _, span := range c.Spans {
+		if isSpecial(span.Kind) {
+			specials = append(specials, span)
+		}
+	}
+	return specials
+}
+
+// keysOf returns the keys of a set, sorted
+func keysOf(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// sameValues reports whether two sorted value lists hold the same values
+func sameValues(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameSpans reports whether two lists of special terms are the same
+func sameSpans(a, b []Span) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package reader
+
+import (
+	"time"
+)
+
+// convertCase is an expression of one dialect and the expression expected of converting it to another; an empty expected expression means conversion fails
+type convertCase struct {
+	from     *Dialect
+	to       *Dialect
+	expr     string
+	expected string
+}
+
+var ConvertTestInputs = []convertCase{
+	{Vixie, Quartz, "*/15 9-17 * * MON-FRI", "0 */15 9-17 ? * MON-FRI"},
+	{Vixie, Quartz, "30 4 1 * *", "0 30 4 1 * ?"},
+	{Vixie, Quartz, "0 0 * * *", "0 0 0 * * ?"},
+	{Vixie, Quartz, "0 0 * * 0,6", "0 0 0 ? * 1,7"},
+	{Vixie, Quartz, "0 0 * * 5-7", "0 0 0 ? * 1,6,7"},
+	{Vixie, Quartz, "@daily", "0 0 0 * * ?"},
+	{Vixie, Quartz, "0 9 1 * MON", ""},
+	{Vixie, Quartz, "0 0 1 * 0-6", "0 0 0 * * ?"},
+	{Vixie, Quartz, "0 0 1-31 * 1", "0 0 0 * * ?"},
+	{Vixie, Quartz, "0 0 */2 * 1", ""},
+	{Vixie, Quartz, "@reboot", ""},
+	{Vixie, AWS, "0 12 * * 1-5", "0 12 ? * 2-6 *"},
+	{Vixie, Kubernetes, "0 0 * * 7", "0 0 * * 0"},
+	{Vixie, Kubernetes, "@weekly", "@weekly"},
+	{Vixie, Cronie, "@reboot", "@reboot"},
+	{Quartz, Vixie, "0 0 12 ? * 2#1", ""},
+	{Quartz, Vixie, "0 0 12 ? * 2-6", "0 12 * * 1-5"},
+	{Quartz, Vixie, "0 0 12 L * ?", ""},
+	{Quartz, Vixie, "30 0 12 * * ?", ""},
+	{Quartz, Vixie, "0 0 12 * * ? 2026", ""},
+	{Quartz, AWS, "0 0 12 ? * 6L 2026", "0 12 ? * 6L 2026"},
+	{Quartz, Kubernetes, "0 0 12 ? * SUN", "0 12 * * SUN"},
+}
+
+// TestConvert tests that converted expressions read in their target dialect, and fire at the same times as the expressions they were converted from
+func (c *CronTab) TestConvert() {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	for _, tc := range ConvertTestInputs {
+		sched, err := tc.from.Parse(tc.expr)
+		c.Require().NoError(err, tc.expr)
+		converted, err := tc.to.Convert(sched)
+		if tc.expected == "" {
+			c.Assert().ErrorIs(err, ErrUnconvertible, "%s to %s", tc.expr, tc.to.Name)
+			continue
+		}
+		c.Require().NoError(err, "%s to %s", tc.expr, tc.to.Name)
+		c.Assert().Equal(tc.expected, converted, "%s to %s", tc.expr, tc.to.Name)
+
+		target, err := tc.to.Parse(converted)
+		c.Require().NoError(err, converted)
+		c.Assert().Equal(sched.NextN(from, 20), target.NextN(from, 20), "%s to %s", tc.expr, tc.to.Name)
+		c.Assert().True(Equivalent(sched, target), "%s to %s", tc.expr, tc.to.Name)
+	}
+}
@@ -0,0 +1,280 @@
+package reader
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// UserCrontab is the format of crontabs installed with crontab(1), where each entry is a schedule followed by a command
+	UserCrontab = iota
+	// SystemCrontab is the format of /etc/crontab and /etc/cron.d files, where each entry is a schedule, the user to run the command as, then the command
+	SystemCrontab
+)
+
+// Crontab holds every entry of a crontab file in the order written, along with the environment assignments made in it
+type Crontab struct {
+	// Kind is the format the crontab was parsed as, UserCrontab or SystemCrontab
+	Kind int
+	// Dialect is the cron implementation the schedules of the crontab are read and validated for
+	Dialect *Dialect
+	Entries []Entry
+	Env     []EnvAssignment
+}
+
+// Entry holds a single scheduled command of a crontab file
+type Entry struct {
+	// Line is the 1-based line number the entry was read from
+	Line int
+	// Comments holds the comment lines directly above the entry, without their leading "#". A blank line ends a comment block.
+	Comments []string
+	// Schedule is the fields or the nickname scheduling the entry, as written
+	Schedule CronRead
+	// User is the user the command runs as. It is only set for entries of a SystemCrontab.
+	User string
+	// Command is the rest of the line following the schedule, and the user where there is one
+	Command string
+}
+
+// EnvAssignment holds a NAME=value line of a crontab file, such as SHELL=/bin/bash or MAILTO=""
+type EnvAssignment struct {
+	// Line is the 1-based line number the assignment was read from
+	Line  int
+	Name  string
+	Value string
+}
+
+// OpenCrontab opens the crontab file passed in as argument and parses every line of it. Files at /etc/crontab or within /etc/cron.d are parsed as a SystemCrontab,
+// and any other file as a UserCrontab; use OpenCrontabKind to choose the format explicitly.
+func OpenCrontab(loc string) (*Crontab, error) {
+	return OpenCrontabKind(loc, KindOf(loc))
+}
+
+// OpenCrontabKind opens the crontab file passed in as argument and parses every line of it in the format of kind. See ParseCrontabKind.
+func OpenCrontabKind(loc string, kind int) (*Crontab, error) {
+	return OpenCrontabDialect(loc, kind, Vixie)
+}
+
+// OpenCrontabDialect opens the crontab file passed in as argument and parses every line of it in the format of kind, reading schedules in the dialect d.
+// See ParseCrontabDialect.
+func OpenCrontabDialect(loc string, kind int, d *Dialect) (*Crontab, error) {
+	file, err := os.Open(loc)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseCrontabDialect(file, kind, d)
+}
+
+// KindOf returns the crontab format expected of the file at loc going by where cron reads it from: SystemCrontab for /etc/crontab and files within /etc/cron.d,
+// and UserCrontab otherwise
+func KindOf(loc string) int {
+	abs, err := filepath.Abs(loc)
+	if err != nil {
+		abs = filepath.Clean(loc)
+	}
+	if abs == "/etc/crontab" || filepath.Dir(abs) == "/etc/cron.d" {
+		return SystemCrontab
+	}
+	return UserCrontab
+}
+
+// ParseCrontab parses a UserCrontab read from r. See ParseCrontabKind.
+func ParseCrontab(r io.Reader) (*Crontab, error) {
+	return ParseCrontabKind(r, UserCrontab)
+}
+
+// ParseSystemCrontab parses a SystemCrontab read from r, as found in /etc/crontab and /etc/cron.d. See ParseCrontabKind.
+func ParseSystemCrontab(r io.Reader) (*Crontab, error) {
+	return ParseCrontabKind(r, SystemCrontab)
+}
+
+// ParseCrontabKind parses a crontab of the format kind read from r, reading schedules in the Vixie dialect. See ParseCrontabDialect.
+func ParseCrontabKind(r io.Reader, kind int) (*Crontab, error) {
+	return ParseCrontabDialect(r, kind, Vixie)
+}
+
+// ParseCrontabDialect parses a crontab of the format kind read from r, reading schedules in the dialect d. Blank lines and "#" comments are skipped,
+// NAME=value lines are collected as environment assignments, and every other line is read as an entry: a schedule, then the user to run as for a SystemCrontab,
+// then its command. Dialects whose scheduler reads no crontab files take each entry as a lone schedule with no command. Lines that cannot be read as either
+// are reported in the error returned, each prefixed with its line number, alongside the Crontab holding every line that could be read.
+func ParseCrontabDialect(r io.Reader, kind int, d *Dialect) (*Crontab, error) {
+	if kind != UserCrontab && kind != SystemCrontab {
+		return nil, errorf(ErrUnknownKind, "unknown crontab kind %d", kind)
+	}
+	tab := &Crontab{Kind: kind, Dialect: d}
+	var errs []error
+	var comments []string
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			comments = nil
+		case strings.HasPrefix(line, "#"):
+			comments = append(comments, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		default:
+			if env, ok := parseEnvAssignment(line); ok {
+				env.Line = lineNo
+				tab.Env = append(tab.Env, env)
+				comments = nil
+				continue
+			}
+			entry, err := parseEntry(line, kind, d)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", lineNo, err))
+				comments = nil
+				continue
+			}
+			entry.Line = lineNo
+			entry.Comments = comments
+			tab.Entries = append(tab.Entries, entry)
+			comments = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tab, errors.Join(errs...)
+}
+
+// Validate validates the schedule of every entry in the crontab against its dialect. The error returned joins the failure of every invalid entry,
+// each prefixed with its line number.
+func (c *Crontab) Validate() (bool, error) {
+	var errs []error
+	for i := 0; i < len(c.Entries); i++ {
+		if _, err := c.Dialect.Validate(c.Entries[i].Schedule.String()); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", c.Entries[i].Line, err))
+		}
+	}
+	if len(errs) > 0 {
+		return false, errors.Join(errs...)
+	}
+	return true, nil
+}
+
+// Location returns the time zone the entry passed in fires in: the zone named by the last CRON_TZ line above it, or failing that by the last TZ line
+// above it, as cronie reads them. It returns nil when neither is set, leaving the zone to the caller. It errors with ErrBadLocation when the zone is unknown.
+func (c *Crontab) Location(e Entry) (*time.Location, error) {
+	var cronTZ, tz *EnvAssignment
+	for i := 0; i < len(c.Env) && c.Env[i].Line < e.Line; i++ {
+		switch c.Env[i].Name {
+		case "CRON_TZ":
+			cronTZ = &c.Env[i]
+		case "TZ":
+			tz = &c.Env[i]
+		}
+	}
+	if cronTZ == nil {
+		cronTZ = tz
+	}
+	if cronTZ == nil {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(cronTZ.Value)
+	if err != nil {
+		return nil, errorf(ErrBadLocation, "line %d: unknown time zone %q in %s", cronTZ.Line, cronTZ.Value, cronTZ.Name)
+	}
+	return loc, nil
+}
+
+// Schedule parses the schedule of the entry passed in following the crontab's dialect, firing in the time zone set for it by the lines above it. See Location.
+func (c *Crontab) Schedule(e Entry) (*Schedule, error) {
+	loc, err := c.Location(e)
+	if err != nil {
+		return nil, err
+	}
+	return c.Dialect.ParseInLocation(e.Schedule.String(), loc)
+}
+
+// parseEntry splits a crontab line into its schedule, user and command. Nicknames take up a single token, while other schedules take up as many as
+// the dialect has fields. The user takes up the token following the schedule in a SystemCrontab.
+func parseEntry(line string, kind int, d *Dialect) (Entry, error) {
+	if !d.Crontab {
+		return Entry{Schedule: CronRead(strings.Join(strings.Fields(line), " "))}, nil
+	}
+	n := d.scheduleFields(line)
+	tokens, rest := splitTokens(line, n)
+	if len(tokens) < n {
+		return Entry{}, errorf(ErrFieldCount, "entry has %d schedule fields, expected %d: %q", len(tokens), n, line)
+	}
+	entry := Entry{Schedule: CronRead(strings.Join(tokens, " "))}
+
+	if kind == SystemCrontab {
+		user, cmd := splitTokens(rest, 1)
+		if len(user) == 0 {
+			return Entry{}, errorf(ErrBadUser, "entry has no user: %q", line)
+		}
+		if !validUser(user[0]) {
+			return Entry{}, errorf(ErrBadUser, "entry has invalid user %q: %q", user[0], line)
+		}
+		entry.User, rest = user[0], cmd
+	}
+
+	if rest == "" {
+		return Entry{}, errorf(ErrNoCommand, "entry has no command: %q", line)
+	}
+	entry.Command = rest
+	return entry, nil
+}
+
+// validUser reports whether s is a portable user name: letters, digits, ".", "_" and "-", not starting with "-", optionally ending in "$"
+func validUser(s string) bool {
+	name := strings.TrimSuffix(s, "$")
+	if name == "" || name[0] == '-' {
+		return false
+	}
+	for _, r := range name {
+		if !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-')) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTokens splits off the first n whitespace separated tokens of s, returning them along with the remainder of s, untouched besides its leading whitespace
+func splitTokens(s string, n int) ([]string, string) {
+	var tokens []string
+	rest := strings.TrimLeftFunc(s, unicode.IsSpace)
+	for len(tokens) < n && rest != "" {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		tokens = append(tokens, rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	return tokens, rest
+}
+
+// parseEnvAssignment reads a NAME=value line, allowing spaces around the "=" and quotes around the name or value as cron does.
+// It reports false when the line is not an assignment.
+func parseEnvAssignment(line string) (EnvAssignment, bool) {
+	name, value, found := strings.Cut(line, "=")
+	if !found {
+		return EnvAssignment{}, false
+	}
+	name = unquote(strings.TrimSpace(name))
+	if name == "" || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return EnvAssignment{}, false
+	}
+	return EnvAssignment{Name: name, Value: unquote(strings.TrimSpace(value))}, true
+}
+
+// unquote strips a matching pair of single or double quotes surrounding s
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
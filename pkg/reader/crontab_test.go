@@ -0,0 +1,117 @@
+package reader
+
+import (
+	"strings"
+)
+
+var CrontabTestFile = `# environment
+SHELL=/bin/bash
+MAILTO = "ops@example.com"
+
+# nightly backup
+# keeps a week of archives
+0 2 * * * /usr/local/bin/backup --keep 7
+@reboot   /usr/bin/startup.sh
+
+*/15 9-17 * * MON-FRI  echo "tick"   >> /tmp/log
+0 9 * *
+30 8 * * 1
+0 99 * * * /bin/true
+`
+
+// TestParseCrontab tests that every line of a crontab file is read into its entries, environment assignments and comments
+func (c *CronTab) TestParseCrontab() {
+	tab, err := ParseCrontab(strings.NewReader(CrontabTestFile))
+	c.Require().NotNil(tab)
+	c.Assert().ErrorContains(err, "line 11: entry has 4 schedule fields")
+	c.Assert().ErrorContains(err, "line 12: entry has no command")
+
+	c.Assert().Equal([]EnvAssignment{
+		{Line: 2, Name: "SHELL", Value: "/bin/bash"},
+		{Line: 3, Name: "MAILTO", Value: "ops@example.com"},
+	}, tab.Env)
+
+	c.Require().Len(tab.Entries, 4)
+	c.Assert().Equal(Entry{
+		Line:     7,
+		Comments: []string{"nightly backup", "keeps a week of archives"},
+		Schedule: CronRead("0 2 * * *"),
+		Command:  "/usr/local/bin/backup --keep 7",
+	}, tab.Entries[0])
+	c.Assert().Equal(Entry{Line: 8, Schedule: CronRead("@reboot"), Command: "/usr/bin/startup.sh"}, tab.Entries[1])
+	c.Assert().Equal(Entry{Line: 10, Schedule: CronRead("*/15 9-17 * * MON-FRI"), Command: `echo "tick"   >> /tmp/log`}, tab.Entries[2])
+	c.Assert().Equal(13, tab.Entries[3].Line)
+
+	isValid, err := tab.Validate()
+	c.Assert().False(isValid)
+	c.Assert().ErrorContains(err, "line 13: ")
+}
+
+var SystemCrontabTestFile = `SHELL=/bin/sh
+PATH=/usr/local/sbin:/usr/local/bin:/sbin:/bin:/usr/sbin:/usr/bin
+
+17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+@reboot www-data /usr/bin/warm-cache
+0 4 * * *
+0 4 * * * backup
+0 4 * * * -rf /tmp
+`
+
+// TestParseSystemCrontab tests that entries of a system crontab carry the user to run as, and that the user must be present
+func (c *CronTab) TestParseSystemCrontab() {
+	tab, err := ParseSystemCrontab(strings.NewReader(SystemCrontabTestFile))
+	c.Require().NotNil(tab)
+	c.Assert().Equal(SystemCrontab, tab.Kind)
+	c.Assert().ErrorContains(err, "line 6: entry has no user")
+	c.Assert().ErrorContains(err, "line 7: entry has no command")
+	c.Assert().ErrorContains(err, `line 8: entry has invalid user "-rf"`)
+
+	c.Require().Len(tab.Entries, 2)
+	c.Assert().Equal(Entry{Line: 4, Schedule: CronRead("17 * * * *"), User: "root", Command: "cd / && run-parts --report /etc/cron.hourly"}, tab.Entries[0])
+	c.Assert().Equal(Entry{Line: 5, Schedule: CronRead("@reboot"), User: "www-data", Command: "/usr/bin/warm-cache"}, tab.Entries[1])
+
+	// the same file read as a user crontab takes the user as part of the command
+	tab, _ = ParseCrontab(strings.NewReader(SystemCrontabTestFile))
+	c.Assert().Equal("root    cd / && run-parts --report /etc/cron.hourly", tab.Entries[0].Command)
+}
+
+// TestKindOf tests that crontab files are parsed in the format cron reads them with
+func (c *CronTab) TestKindOf() {
+	c.Assert().Equal(SystemCrontab, KindOf("/etc/crontab"))
+	c.Assert().Equal(SystemCrontab, KindOf("/etc/cron.d/logrotate"))
+	c.Assert().Equal(SystemCrontab, KindOf("/etc/cron.d/../cron.d/php"))
+	c.Assert().Equal(UserCrontab, KindOf("/var/spool/cron/crontabs/root"))
+	c.Assert().Equal(UserCrontab, KindOf("crontab"))
+}
+
+var ZonedCrontabTestFile = `0 9 * * * /bin/local
+TZ=Europe/London
+0 9 * * * /bin/london
+CRON_TZ=Asia/Tokyo
+0 9 * * * /bin/tokyo
+CRON_TZ=Mars/Olympus_Mons
+0 9 * * * /bin/mars
+`
+
+// TestCrontabLocation tests that CRON_TZ and TZ lines set the time zone of the entries below them, with CRON_TZ taking precedence
+func (c *CronTab) TestCrontabLocation() {
+	tab, err := ParseCrontab(strings.NewReader(ZonedCrontabTestFile))
+	c.Require().NoError(err)
+	c.Require().Len(tab.Entries, 4)
+
+	sched, err := tab.Schedule(tab.Entries[0])
+	c.Require().NoError(err)
+	c.Assert().Nil(sched.Location)
+
+	sched, err = tab.Schedule(tab.Entries[1])
+	c.Require().NoError(err)
+	c.Assert().Equal("Europe/London", sched.Location.String())
+
+	sched, err = tab.Schedule(tab.Entries[2])
+	c.Require().NoError(err)
+	c.Assert().Equal("Asia/Tokyo", sched.Location.String())
+
+	_, err = tab.Schedule(tab.Entries[3])
+	c.Assert().ErrorIs(err, ErrBadLocation)
+	c.Assert().ErrorContains(err, "line 6: ")
+}
@@ -0,0 +1,222 @@
+package reader
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// SeverityError marks a diagnostic that makes the expression invalid
+	SeverityError = iota
+	// SeverityWarning marks a diagnostic on a valid expression that likely does not do what its author meant
+	SeverityWarning
+)
+
+// Diagnostic describes one problem found in a cron expression, positioned at the characters responsible for it
+type Diagnostic struct {
+	// Field is the name of the field the problem was found in; it is empty for problems with the expression as a whole
+	Field string
+	// Offset is the byte offset into the expression of the first character responsible, and Length the number of bytes responsible
+	Offset int
+	Length int
+	// Column is the 1-based column of the first character responsible, counted in characters rather than bytes
+	Column int
+	// Token is the text responsible for the problem
+	Token    string
+	Severity int
+	Message  string
+	// Kind is the sentinel error classifying an error diagnostic, such as ErrOutOfRange; it is nil for warnings
+	Kind error
+}
+
+// Error formats the diagnostic as a single line naming its field, its message and its column
+func (d Diagnostic) Error() string {
+	msg := d.Message
+	if d.Field != "" {
+		msg = d.Field + ": " + msg
+	}
+	return fmt.Sprintf("%s (column %d)", msg, d.Column)
+}
+
+// Unwrap returns the sentinel error classifying the diagnostic, so that errors.Is(err, ErrOutOfRange) holds for an error joining diagnostics
+func (d Diagnostic) Unwrap() error {
+	return d.Kind
+}
+
+// Render formats the diagnostic over three lines: its severity and message, the expression it was found in, and a caret line pointing at the
+// characters responsible, the way a compiler points at bad source
+//
+//	error: hour: number 24 not within acceptable bounds 0-23
+//	0 24 * * *
+//	  ^^
+func (d Diagnostic) Render(expr string) string {
+	msg := d.Message
+	if d.Field != "" {
+		msg = d.Field + ": " + msg
+	}
+
+	offset := d.Offset
+	if offset > len(expr) {
+		offset = len(expr)
+	}
+	// keep tabs in the caret line so it lines up with the expression above it
+	var pad strings.Builder
+	for _, r := range expr[:offset] {
+		if r == '\t' {
+			pad.WriteRune('\t')
+			continue
+		}
+		pad.WriteRune(' ')
+	}
+	width := 1
+	if end := offset + d.Length; d.Length > 0 && end <= len(expr) {
+		width = utf8.RuneCountInString(expr[offset:end])
+	}
+	return fmt.Sprintf("%s: %s\n%s\n%s%s", SeverityName(d.Severity), msg, expr, pad.String(), strings.Repeat("^", width))
+}
+
+// SeverityName returns the name of a diagnostic severity, "error" or "warning"
+func SeverityName(severity int) string {
+	if severity == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnose checks a CronRead value as a Vixie cron expression, returning every problem found in it. See Dialect.Diagnose.
+func (cr *CronRead) Diagnose() []Diagnostic {
+	return Vixie.Diagnose(cr.String())
+}
+
+// Diagnose checks a cron expression string against the rules of the dialect, returning every problem found in it, positioned within the expression.
+// Errors make the expression invalid; warnings point out valid expressions that likely do not fire when their author meant them to.
+func (d *Dialect) Diagnose(expr string) []Diagnostic {
+	_, diags := d.decode(expr)
+	return diags
+}
+
+// parseError is a failure to parse part of a field token, positioned at the bytes of the token responsible for it
+type parseError struct {
+	offset int
+	length int
+	err    error
+}
+
+func (e *parseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *parseError) Unwrap() error {
+	return e.err
+}
+
+// errorAt positions err at the bytes [offset, offset+length) of the enclosing token. When err is already positioned within that part of the token,
+// it is shifted to where the part starts instead.
+func errorAt(offset, length int, err error) error {
+	if pe, ok := err.(*parseError); ok {
+		return &parseError{offset: offset + pe.offset, length: pe.length, err: pe.err}
+	}
+	return &parseError{offset: offset, length: length, err: err}
+}
+
+// token is a whitespace separated field of a cron expression along with its byte offset in the expression
+type token struct {
+	text   string
+	offset int
+}
+
+// tokenize splits a cron expression into its whitespace separated fields, keeping where each one starts
+func tokenize(expr string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range expr {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			tokens = append(tokens, token{text: expr[start:i], offset: start})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{text: expr[start:], offset: start})
+	}
+	return tokens
+}
+
+// newDiagnostic builds a diagnostic covering the bytes [offset, offset+length) of expr, reporting err and the sentinel error classifying it
+func newDiagnostic(expr, field string, offset, length, severity int, err error) Diagnostic {
+	if offset > len(expr) {
+		offset = len(expr)
+	}
+	end := offset + length
+	if end > len(expr) {
+		end = len(expr)
+	}
+	return Diagnostic{
+		Field:    field,
+		Offset:   offset,
+		Length:   end - offset,
+		Column:   utf8.RuneCountInString(expr[:offset]) + 1,
+		Token:    expr[offset:end],
+		Severity: severity,
+		Message:  err.Error(),
+		Kind:     kindOf(err),
+	}
+}
+
+// fieldDiagnostic turns the error from parsing the field token tok into a diagnostic, positioned at the part of the token responsible when the error carries one
+func fieldDiagnostic(expr, field string, tok token, err error) Diagnostic {
+	if pe, ok := err.(*parseError); ok {
+		return newDiagnostic(expr, field, tok.offset+pe.offset, pe.length, SeverityError, pe.err)
+	}
+	return newDiagnostic(expr, field, tok.offset, len(tok.text), SeverityError, err)
+}
+
+// daysInMonth holds the most days each month can have, indexed from January as 1
+var daysInMonth = []int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// warnings points out the valid expressions of the dialect that likely do not fire when their author meant them to:
+// day fields combined by either matching rather than both, and days of the month that never occur in the months selected
+func (d *Dialect) warnings(expr string, tokens []token, dec *CronExpressionDecoded) []Diagnostic {
+	var diags []Diagnostic
+	dom, domOk := d.tokenOf(DayOfTheMonth, tokens)
+	dow, dowOk := d.tokenOf(DayOfTheWeek, tokens)
+	if !domOk || !dowOk {
+		return nil
+	}
+
+	domRestricted := !strings.HasPrefix(dom.text, "*") && dec.DayOfMonth.DelimKind != DelimAny
+	dowRestricted := !strings.HasPrefix(dow.text, "*") && dec.DayOfWeek.DelimKind != DelimAny
+	if d.Days == DaysEither && domRestricted && dowRestricted {
+		diags = append(diags, newDiagnostic(expr, DayOfTheWeek, dow.offset, len(dow.text), SeverityWarning,
+			fmt.Errorf("both day fields are restricted, so the schedule fires on days matching either %q or %q rather than both", dom.text, dow.text)))
+	}
+
+	if domRestricted && (d.Days != DaysEither || !dowRestricted) && dec.Month.Spans != nil {
+		fires := len(dec.DayOfMonth.Values()) == 0
+		months := dec.Month.Values()
+		for _, day := range dec.DayOfMonth.Values() {
+			for i := 0; i < len(months) && !fires; i++ {
+				fires = day <= daysInMonth[months[i]]
+			}
+		}
+		if !fires {
+			diags = append(diags, newDiagnostic(expr, DayOfTheMonth, dom.offset, len(dom.text), SeverityWarning,
+				fmt.Errorf("the schedule never fires, as no month selected by %q has a day %s", dec.Month.Raw, dom.text)))
+		}
+	}
+	return diags
+}
+
+// tokenOf returns the token of the named field among the tokens of an expression in the dialect
+func (d *Dialect) tokenOf(name string, tokens []token) (token, bool) {
+	for i := 0; i < len(d.Fields) && i < len(tokens); i++ {
+		if d.Fields[i].Name == name {
+			return tokens[i], true
+		}
+	}
+	return token{}, false
+}
@@ -0,0 +1,90 @@
+package reader
+
+// DiagnosticTestInputs maps expressions to the single diagnostic expected of them
+var DiagnosticTestInputs = map[string]Diagnostic{
+	"0 24 * * *": {
+		Field: Hour, Offset: 2, Length: 2, Column: 3, Token: "24", Severity: SeverityError,
+		Message: "number 24 not within acceptable bounds 0-23", Kind: ErrOutOfRange,
+	},
+	"1-5,10,20-70/2 * * * *": {
+		Field: Minute, Offset: 10, Length: 2, Column: 11, Token: "70", Severity: SeverityError,
+		Message: "number 70 not within acceptable bounds 0-59", Kind: ErrOutOfRange,
+	},
+	"*/0 * * * *": {
+		Field: Minute, Offset: 2, Length: 1, Column: 3, Token: "0", Severity: SeverityError,
+		Message: "step 0 not within acceptable bounds 1-60", Kind: ErrBadStep,
+	},
+	"0 5/10 * * *": {
+		Field: Hour, Offset: 2, Length: 4, Column: 3, Token: "5/10", Severity: SeverityError,
+		Message: `step after the single value "5", write a range such as 5-23/10`, Kind: ErrBadStep,
+	},
+	"0 9 * * MON-FOO": {
+		Field: DayOfTheWeek, Offset: 12, Length: 3, Column: 13, Token: "FOO", Severity: SeverityError,
+		Message: `value "FOO" is neither a number nor a known name`, Kind: ErrBadValue,
+	},
+	"0 9 * 5-1 *": {
+		Field: Month, Offset: 6, Length: 3, Column: 7, Token: "5-1", Severity: SeverityError,
+		Message: "range start 5 is greater than range end 1", Kind: ErrBadRange,
+	},
+	"0 9 * *": {
+		Offset: 7, Length: 0, Column: 8, Token: "", Severity: SeverityError,
+		Message: "cron expression has 4 fields, expected 5", Kind: ErrFieldCount,
+	},
+	"0 9 * * * /bin/true": {
+		Offset: 10, Length: 9, Column: 11, Token: "/bin/true", Severity: SeverityError,
+		Message: "cron expression has 6 fields, expected 5", Kind: ErrFieldCount,
+	},
+	"@fortnightly": {
+		Offset: 0, Length: 12, Column: 1, Token: "@fortnightly", Severity: SeverityError,
+		Message: `unknown nickname "@fortnightly"`, Kind: ErrUnknownMacro,
+	},
+	"0 9 1 * MON": {
+		Field: DayOfTheWeek, Offset: 8, Length: 3, Column: 9, Token: "MON", Severity: SeverityWarning,
+		Message: `both day fields are restricted, so the schedule fires on days matching either "1" or "MON" rather than both`,
+	},
+	"0 0 30 FEB *": {
+		Field: DayOfTheMonth, Offset: 4, Length: 2, Column: 5, Token: "30", Severity: SeverityWarning,
+		Message: `the schedule never fires, as no month selected by "FEB" has a day 30`,
+	},
+}
+
+// TestDiagnose tests that diagnostics are positioned at the characters responsible for them
+func (c *CronTab) TestDiagnose() {
+	for expr, expected := range DiagnosticTestInputs {
+		diags := Vixie.Diagnose(expr)
+		c.Assert().Equal([]Diagnostic{expected}, diags, expr)
+	}
+
+	c.Assert().Empty(Vixie.Diagnose("*/15 9-17 * * MON-FRI"))
+
+	isValid, err := Vixie.Validate("0 0 30 FEB *")
+	c.Assert().True(isValid, "warnings leave an expression valid")
+	c.Assert().NoError(err)
+}
+
+// TestDiagnoseQuartz tests that the Quartz day field rules are diagnosed at the day of month field, and special terms at the part that is wrong
+func (c *CronTab) TestDiagnoseQuartz() {
+	diags := Quartz.Diagnose("0 0 12 * * *")
+	c.Require().Len(diags, 1)
+	c.Assert().Equal(DayOfTheMonth, diags[0].Field)
+	c.Assert().Equal(7, diags[0].Offset)
+
+	diags = Quartz.Diagnose("0 0 12 ? * 6#9")
+	c.Require().Len(diags, 1)
+	c.Assert().Equal("9", diags[0].Token)
+	c.Assert().Equal(13, diags[0].Offset)
+}
+
+// TestRenderDiagnostic tests that rendered diagnostics point a caret line at the characters responsible
+func (c *CronTab) TestRenderDiagnostic() {
+	expr := "0 24 * * *"
+	diags := Vixie.Diagnose(expr)
+	c.Require().Len(diags, 1)
+	c.Assert().Equal("error: hour: number 24 not within acceptable bounds 0-23\n0 24 * * *\n  ^^", diags[0].Render(expr))
+	c.Assert().Equal("hour: number 24 not within acceptable bounds 0-23 (column 3)", diags[0].Error())
+
+	expr = "0\t9 * *"
+	diags = Vixie.Diagnose(expr)
+	c.Require().Len(diags, 1)
+	c.Assert().Equal("error: cron expression has 4 fields, expected 5\n0\t9 * *\n \t     ^", diags[0].Render(expr))
+}
@@ -0,0 +1,271 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// How a dialect combines the day of month and day of week fields into the days a schedule fires on
+const (
+	// DaysEither fires on days matching either day field when both are restricted, and on days matching the restricted one otherwise, as Vixie cron does
+	DaysEither = iota
+	// DaysBoth fires only on days matching both day fields
+	DaysBoth
+	// DaysExclusive requires exactly one of the day fields to be the "?" placeholder, leaving the other to decide, as Quartz does
+	DaysExclusive
+)
+
+// FieldSpec describes one field of a cron expression: the values it accepts, the names that may be written in place of them, whether it accepts
+// the "?" placeholder, and which of the Quartz special terms it accepts
+type FieldSpec struct {
+	Name     string
+	Low      int
+	High     int
+	Aliases  map[string]int
+	Any      bool
+	Specials int
+	// StepFrom accepts a stepped single value such as "5/10", read as the value up to the end of the field, as Quartz does; Vixie cron rejects it
+	StepFrom bool
+	// Equal maps values that stand for another value of the field, such as 7 standing for Sunday, 0, in the day of week field of Vixie cron
+	Equal map[int]int
+}
+
+// Dialect describes the rules of one cron implementation: the fields of its expressions in the order written, the nicknames it accepts,
+// and how it combines its day fields. Validation and decoding follow the dialect's rules, so that expressions can be checked against the scheduler that will run them.
+type Dialect struct {
+	Name   string
+	Fields []FieldSpec
+	// Optional is how many of the trailing Fields may be left out
+	Optional int
+	// Macros maps the nicknames the dialect accepts to the expression they stand for; it is nil when nicknames are not accepted
+	Macros map[string]string
+	// Days is DaysEither, DaysBoth or DaysExclusive
+	Days int
+	// Crontab reports whether the scheduler reads crontab files, where each schedule is followed by its command
+	Crontab bool
+}
+
+var (
+	// Vixie is the dialect of Vixie cron, the cron of most Linux distributions and BSDs. Days of the week count from Sunday as 0, with 7 also standing for Sunday.
+	Vixie = &Dialect{
+		Name:    "vixie",
+		Fields:  fieldsOf(Bounds, fieldOrder),
+		Macros:  Macros,
+		Days:    DaysEither,
+		Crontab: true,
+	}
+	// Cronie is the dialect of cronie, the Vixie cron fork shipped by Fedora and RHEL
+	Cronie = &Dialect{
+		Name:    "cronie",
+		Fields:  fieldsOf(Bounds, fieldOrder),
+		Macros:  Macros,
+		Days:    DaysEither,
+		Crontab: true,
+	}
+	// Quartz is the dialect of the Quartz and Spring schedulers: seconds, minutes, hours, day of month, month, day of week and an optional year.
+	// Exactly one of the day fields must be "?", and the day fields accept the special terms L, W, LW and #.
+	Quartz = &Dialect{
+		Name: "quartz",
+		Fields: []FieldSpec{
+			{Name: Second, Low: 0, High: 59, StepFrom: true},
+			{Name: Minute, Low: 0, High: 59, StepFrom: true},
+			{Name: Hour, Low: 0, High: 23, StepFrom: true},
+			{Name: DayOfTheMonth, Low: 1, High: 31, StepFrom: true, Any: true, Specials: SpecialsDayOfMonth},
+			{Name: Month, Low: 1, High: 12, StepFrom: true, Aliases: MonthNames},
+			{Name: DayOfTheWeek, Low: 1, High: 7, StepFrom: true, Aliases: QuartzDayNames, Any: true, Specials: SpecialsDayOfWeek},
+			{Name: Year, Low: 1970, High: 2099, StepFrom: true},
+		},
+		Optional: 1,
+		Days:     DaysExclusive,
+	}
+	// AWS is the dialect of Amazon EventBridge schedule expressions: minutes, hours, day of month, month, day of week and year, with the day fields
+	// following the Quartz rules
+	AWS = &Dialect{
+		Name: "aws",
+		Fields: []FieldSpec{
+			{Name: Minute, Low: 0, High: 59, StepFrom: true},
+			{Name: Hour, Low: 0, High: 23, StepFrom: true},
+			{Name: DayOfTheMonth, Low: 1, High: 31, StepFrom: true, Any: true, Specials: SpecialsDayOfMonth},
+			{Name: Month, Low: 1, High: 12, StepFrom: true, Aliases: MonthNames},
+			{Name: DayOfTheWeek, Low: 1, High: 7, StepFrom: true, Aliases: QuartzDayNames, Any: true, Specials: SpecialsDayOfWeek},
+			{Name: Year, Low: 1970, High: 2199, StepFrom: true},
+		},
+		Days: DaysExclusive,
+	}
+	// Kubernetes is the dialect of Kubernetes CronJob schedules: five fields counting days of the week from Sunday as 0, "?" read as "*" in the day fields,
+	// and every nickname but @reboot
+	Kubernetes = &Dialect{
+		Name: "kubernetes",
+		Fields: []FieldSpec{
+			{Name: Minute, Low: 0, High: 59},
+			{Name: Hour, Low: 0, High: 23},
+			{Name: DayOfTheMonth, Low: 1, High: 31, Any: true},
+			{Name: Month, Low: 1, High: 12, Aliases: MonthNames},
+			{Name: DayOfTheWeek, Low: 0, High: 6, Aliases: DayNames, Any: true},
+		},
+		Macros: map[string]string{
+			"@yearly":   Macros["@yearly"],
+			"@annually": Macros["@annually"],
+			"@monthly":  Macros["@monthly"],
+			"@weekly":   Macros["@weekly"],
+			"@daily":    Macros["@daily"],
+			"@midnight": Macros["@midnight"],
+			"@hourly":   Macros["@hourly"],
+		},
+		Days: DaysEither,
+	}
+)
+
+// Dialects holds the built-in dialects by name
+var Dialects = map[string]*Dialect{
+	Vixie.Name:      Vixie,
+	Cronie.Name:     Cronie,
+	Quartz.Name:     Quartz,
+	AWS.Name:        AWS,
+	Kubernetes.Name: Kubernetes,
+}
+
+// fieldsOf lays out the FieldSpec of each named field in the order the names are passed in
+func fieldsOf(specs map[string]FieldSpec, names []string) []FieldSpec {
+	fields := make([]FieldSpec, 0, len(names))
+	for i := 0; i < len(names); i++ {
+		fields = append(fields, specs[names[i]])
+	}
+	return fields
+}
+
+// Spec returns the FieldSpec of the named field in the dialect. It reports false when the dialect has no such field.
+func (d *Dialect) Spec(name string) (FieldSpec, bool) {
+	for i := 0; i < len(d.Fields); i++ {
+		if d.Fields[i].Name == name {
+			return d.Fields[i], true
+		}
+	}
+	return FieldSpec{}, false
+}
+
+// LookupDialect returns the built-in dialect of the name passed in, matched case-insensitively
+func LookupDialect(name string) (*Dialect, error) {
+	d, ok := Dialects[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(Dialects))
+		for n := range Dialects {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, errorf(ErrUnknownDialect, "unknown dialect %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return d, nil
+}
+
+// Validate validates a cron expression string against the rules of the dialect. It checks that the expression is a nickname the dialect accepts,
+// or that it has as many fields as the dialect expects, each following the cron grammar within the bounds for its position.
+// The error returned joins the failure of every invalid field; use Diagnose to have each one positioned within the expression.
+func (d *Dialect) Validate(expr string) (bool, error) {
+	if errs := errorsOf(d.Diagnose(expr)); len(errs) > 0 {
+		return false, fmt.Errorf("encountered %d validation errors: %w", len(errs), errors.Join(errs...))
+	}
+	return true, nil
+}
+
+// Decode converts a cron expression string into its CronExpressionDecoded following the rules of the dialect. Nicknames decode into the expression
+// they stand for, with Macro recording the nickname; @reboot decodes into a TriggerReboot with no time fields.
+func (d *Dialect) Decode(expr string) (*CronExpressionDecoded, error) {
+	dec, diags := d.decode(expr)
+	if errs := errorsOf(diags); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return dec, nil
+}
+
+// errorsOf returns the diagnostics of error severity, leaving out warnings
+func errorsOf(diags []Diagnostic) []error {
+	var errs []error
+	for i := 0; i < len(diags); i++ {
+		if diags[i].Severity == SeverityError {
+			errs = append(errs, diags[i])
+		}
+	}
+	return errs
+}
+
+// decode decodes expr following the rules of the dialect, diagnosing every problem found in it. The decoded expression is nil when any of
+// the diagnostics is an error.
+func (d *Dialect) decode(expr string) (*CronExpressionDecoded, []Diagnostic) {
+	if isMacro(expr) {
+		start := strings.Index(expr, "@")
+		nickname := strings.TrimSpace(expr)
+		if d.Macros == nil {
+			return nil, []Diagnostic{newDiagnostic(expr, "", start, len(nickname), SeverityError, errorf(ErrUnknownMacro, "the %s dialect does not accept nicknames", d.Name))}
+		}
+		expanded, err := expandMacro(expr, d.Macros)
+		if err != nil {
+			return nil, []Diagnostic{newDiagnostic(expr, "", start, len(nickname), SeverityError, err)}
+		}
+		if expanded == "" {
+			dec := &CronExpressionDecoded{Macro: nickname, Trigger: TriggerReboot}
+			dec.remember(nickname)
+			return dec, nil
+		}
+		dec, diags := d.decode(expanded)
+		if dec != nil {
+			dec.Macro = nickname
+			dec.remember(nickname)
+		}
+		return dec, diags
+	}
+
+	tokens := tokenize(expr)
+	if len(tokens) < len(d.Fields)-d.Optional || len(tokens) > len(d.Fields) {
+		expected := strconv.Itoa(len(d.Fields))
+		if d.Optional > 0 {
+			expected = fmt.Sprintf("%d to %d", len(d.Fields)-d.Optional, len(d.Fields))
+		}
+		msg := errorf(ErrFieldCount, "cron expression has %d fields, expected %s", len(tokens), expected)
+		if len(tokens) > len(d.Fields) {
+			// point at the first field too many
+			extra := tokens[len(d.Fields)]
+			return nil, []Diagnostic{newDiagnostic(expr, "", extra.offset, len(expr)-extra.offset, SeverityError, msg)}
+		}
+		// point just past the last field, where the missing ones belong
+		end := len(strings.TrimRightFunc(expr, unicode.IsSpace))
+		if end > 0 {
+			end++
+		}
+		return nil, []Diagnostic{newDiagnostic(expr, "", end, 0, SeverityError, msg)}
+	}
+
+	dec := &CronExpressionDecoded{}
+	var diags []Diagnostic
+	for i := 0; i < len(tokens); i++ {
+		catch, err := parseField(tokens[i].text, d.Fields[i])
+		if err != nil {
+			diags = append(diags, fieldDiagnostic(expr, d.Fields[i].Name, tokens[i], err))
+			continue
+		}
+		dec.set(d.Fields[i].Name, catch)
+	}
+	if len(diags) > 0 {
+		return nil, diags
+	}
+	dec.remember(strings.TrimSpace(expr))
+
+	if d.Days == DaysExclusive && (dec.DayOfMonth.DelimKind == DelimAny) == (dec.DayOfWeek.DelimKind == DelimAny) {
+		dom, _ := d.tokenOf(DayOfTheMonth, tokens)
+		return nil, []Diagnostic{newDiagnostic(expr, DayOfTheMonth, dom.offset, len(dom.text), SeverityError,
+			errorf(ErrPlaceholder, "exactly one of the %s and %s fields must be \"?\" in the %s dialect", DayOfTheMonth, DayOfTheWeek, d.Name))}
+	}
+	return dec, d.warnings(expr, tokens, dec)
+}
+
+// scheduleFields returns how many whitespace separated tokens the schedule of a crontab line takes up in the dialect
+func (d *Dialect) scheduleFields(line string) int {
+	if d.Macros != nil && isMacro(line) {
+		return 1
+	}
+	return len(d.Fields)
+}
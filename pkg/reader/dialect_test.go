@@ -0,0 +1,182 @@
+package reader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DialectTestInputs holds, for each built-in dialect, expressions and whether that dialect accepts them
+var DialectTestInputs = map[*Dialect]state{
+	Vixie: state{
+		[]string{
+			"*/5 * * * *",  // five fields
+			"@reboot",      // startup nickname
+			"0 0 12 * * ?", // seconds field
+			"0 12 L * ?",   // quartz specials
+		},
+		[]bool{
+			true,  // five fields
+			true,  // startup nickname
+			false, // seconds field
+			false, // quartz specials
+		},
+	},
+	Quartz: state{
+		[]string{
+			"0 0/15 9-17 ? * MON-FRI 2026", // seven fields
+			"0 0 12 L * ?",                 // last day of month
+			"*/5 * * * *",                  // five fields
+			"@daily",                       // nickname
+		},
+		[]bool{
+			true,  // seven fields
+			true,  // last day of month
+			false, // five fields
+			false, // nickname
+		},
+	},
+	AWS: state{
+		[]string{
+			"0 18 ? * MON-FRI *",   // weekdays
+			"15 10 ? * 6L 2026",    // last Friday
+			"0/10 * ? * MON-FRI *", // stepped minutes
+			"0 18 * * MON-FRI *",   // neither day field is ?
+			"0 18 ? * MON-FRI",     // missing year
+			"0 0 18 ? * MON-FRI *", // seconds field
+		},
+		[]bool{
+			true,  // weekdays
+			true,  // last Friday
+			true,  // stepped minutes
+			false, // neither day field is ?
+			false, // missing year
+			false, // seconds field
+		},
+	},
+	Kubernetes: state{
+		[]string{
+			"*/5 * * * *", // five fields
+			"0 9 * * 0",   // sunday as 0
+			"0 9 ? * SUN", // ? as a wildcard
+			"@hourly",     // nickname
+			"@reboot",     // startup nickname
+			"0 9 * * 7",   // sunday as 7
+			"0 24 * * *",  // hour over bounds
+		},
+		[]bool{
+			true,  // five fields
+			true,  // sunday as 0
+			true,  // ? as a wildcard
+			true,  // nickname
+			false, // startup nickname
+			false, // sunday as 7
+			false, // hour over bounds
+		},
+	},
+}
+
+// TestDialectValidation tests that every built-in dialect accepts and rejects expressions by its own rules
+func (c *CronTab) TestDialectValidation() {
+	for d, v := range DialectTestInputs {
+		for i := 0; i < len(v.input); i++ {
+			actual, _ := d.Validate(v.input[i])
+			c.Assert().Equal(v.expected[i], actual, fmt.Sprintf("%s: expected %v, got %v. input: %v", d.Name, v.expected[i], actual, v.input[i]))
+		}
+	}
+}
+
+// TestLookupDialect tests that built-in dialects are found by name
+func (c *CronTab) TestLookupDialect() {
+	d, err := LookupDialect("Quartz")
+	c.Require().NoError(err)
+	c.Assert().Same(Quartz, d)
+
+	_, err = LookupDialect("systemd")
+	c.Assert().ErrorContains(err, `unknown dialect "systemd"`)
+}
+
+// TestParseCrontabDialect tests that dialects without crontab files read each line as a lone schedule
+func (c *CronTab) TestParseCrontabDialect() {
+	tab, err := ParseCrontabDialect(strings.NewReader("# quartz triggers\n0 0/15 9-17 ? * MON-FRI 2026\n0 0 12 * * *\n"), UserCrontab, Quartz)
+	c.Require().NoError(err)
+	c.Require().Len(tab.Entries, 2)
+	c.Assert().Equal(CronRead("0 0/15 9-17 ? * MON-FRI 2026"), tab.Entries[0].Schedule)
+	c.Assert().Empty(tab.Entries[0].Command)
+
+	isValid, err := tab.Validate()
+	c.Assert().False(isValid)
+	c.Assert().ErrorContains(err, "line 3: ")
+}
+
+// BoundaryTestInputs holds the inclusive bounds each built-in dialect is expected to accept for every one of its fields
+var BoundaryTestInputs = map[*Dialect]map[string][2]int{
+	Vixie: {
+		Minute: {0, 59}, Hour: {0, 23}, DayOfTheMonth: {1, 31}, Month: {1, 12}, DayOfTheWeek: {0, 7},
+	},
+	Cronie: {
+		Minute: {0, 59}, Hour: {0, 23}, DayOfTheMonth: {1, 31}, Month: {1, 12}, DayOfTheWeek: {0, 7},
+	},
+	Quartz: {
+		Second: {0, 59}, Minute: {0, 59}, Hour: {0, 23}, DayOfTheMonth: {1, 31}, Month: {1, 12}, DayOfTheWeek: {1, 7}, Year: {1970, 2099},
+	},
+	AWS: {
+		Minute: {0, 59}, Hour: {0, 23}, DayOfTheMonth: {1, 31}, Month: {1, 12}, DayOfTheWeek: {1, 7}, Year: {1970, 2199},
+	},
+	Kubernetes: {
+		Minute: {0, 59}, Hour: {0, 23}, DayOfTheMonth: {1, 31}, Month: {1, 12}, DayOfTheWeek: {0, 6},
+	},
+}
+
+// TestBoundaryMatrix tests that every field of every built-in dialect accepts its lower and upper bounds, and rejects the values just outside them
+func (c *CronTab) TestBoundaryMatrix() {
+	for d, bounds := range BoundaryTestInputs {
+		c.Require().Len(d.Fields, len(bounds), d.Name)
+		for i := 0; i < len(d.Fields); i++ {
+			name := d.Fields[i].Name
+			b, ok := bounds[name]
+			c.Require().True(ok, "%s: no bounds expected for %s", d.Name, name)
+			cases := map[int]bool{b[0] - 1: false, b[0]: true, b[1]: true, b[1] + 1: false}
+			for value, expected := range cases {
+				expr := boundaryExpression(d, i, strconv.Itoa(value))
+				actual, err := d.Validate(expr)
+				c.Assert().Equal(expected, actual, fmt.Sprintf("%s %s: expected %v, got %v. input: %v, error: %v", d.Name, name, expected, actual, expr, err))
+			}
+		}
+	}
+}
+
+// TestWeekdaySeven tests that weekday 7 stands for Sunday in Vixie cron, both alone and at the end of a range
+func (c *CronTab) TestWeekdaySeven() {
+	for _, expr := range []string{"0 9 * * 7", "0 9 * * 0", "0 9 * * SUN", "0 9 * * sun,7"} {
+		dec, err := Vixie.Decode(expr)
+		c.Require().NoError(err, expr)
+		c.Assert().Equal([]int{0}, dec.DayOfWeek.Values(), expr)
+	}
+	dec, err := Vixie.Decode("0 9 * * FRI-7")
+	c.Require().NoError(err)
+	c.Assert().Equal([]int{0, 5, 6}, dec.DayOfWeek.Values())
+	c.Assert().Equal([]Span{{Low: 5, High: 7, Kind: DelimRange}}, dec.DayOfWeek.Spans)
+}
+
+// boundaryExpression writes an expression valid in the dialect d, besides the field at position i which is set to value
+func boundaryExpression(d *Dialect, i int, value string) string {
+	pieces := make([]string, len(d.Fields))
+	for j := 0; j < len(d.Fields); j++ {
+		pieces[j] = "*"
+	}
+	// dialects needing "?" in one of the day fields get it in whichever is not under test
+	if d.Days == DaysExclusive {
+		placeholder := DayOfTheWeek
+		if d.Fields[i].Name == DayOfTheWeek {
+			placeholder = DayOfTheMonth
+		}
+		for j := 0; j < len(d.Fields); j++ {
+			if d.Fields[j].Name == placeholder {
+				pieces[j] = "?"
+			}
+		}
+	}
+	pieces[i] = value
+	return strings.Join(pieces, " ")
+}
@@ -0,0 +1,55 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors classifying why a cron expression or crontab line failed to parse. Every error returned by the reader wraps one of them,
+// so callers can tell failures apart with errors.Is, and reach the positioned Diagnostic with errors.As.
+var (
+	ErrFieldCount     = errors.New("wrong number of fields")
+	ErrEmpty          = errors.New("empty field or list element")
+	ErrBadValue       = errors.New("value is neither a number nor a known name")
+	ErrOutOfRange     = errors.New("value out of range")
+	ErrBadRange       = errors.New("range start after range end")
+	ErrBadStep        = errors.New("invalid step")
+	ErrPlaceholder    = errors.New("misplaced \"?\" placeholder")
+	ErrBadSpecial     = errors.New("invalid special term")
+	ErrUnknownMacro   = errors.New("unknown nickname")
+	ErrNoCommand      = errors.New("entry has no command")
+	ErrBadUser        = errors.New("entry has no valid user")
+	ErrNoExpression   = errors.New("no cron expression found")
+	ErrUnknownKind    = errors.New("unknown crontab kind")
+	ErrUnknownDialect = errors.New("unknown dialect")
+	ErrBadLocation    = errors.New("unknown time zone")
+	ErrUnconvertible  = errors.New("schedule cannot be expressed in the target dialect")
+)
+
+// kindError is an error message classified by one of the sentinel errors, which it unwraps to
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf formats an error message classified by the sentinel error kind, so that errors.Is(err, kind) holds while the message stays as formatted
+func errorf(kind error, format string, a ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, a...)}
+}
+
+// kindOf returns the sentinel error classifying err, or nil when it carries none
+func kindOf(err error) error {
+	var ke *kindError
+	if errors.As(err, &ke) {
+		return ke.kind
+	}
+	return nil
+}
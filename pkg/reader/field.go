@@ -0,0 +1,254 @@
+package reader
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Span holds one comma-separated term of a cron field: a single value, a range, or the wildcard, each optionally stepped.
+// A single value has Low == High, and a wildcard spans the whole bounds of its field. Step is zero when the term carries no "/n" suffix.
+// Quartz special terms keep their day in Low and High, the m of "n#m" in Nth, and the n of "L-n" in Offset.
+type Span struct {
+	Low    int
+	High   int
+	Step   int
+	Kind   int
+	Nth    int
+	Offset int
+}
+
+// Values expands the span into every value it matches, in ascending order. Quartz special terms match values that depend on the calendar, and expand to none.
+func (s Span) Values() []int {
+	if isSpecial(s.Kind) {
+		return nil
+	}
+	step := s.Step
+	if step < 1 {
+		step = 1
+	}
+	var vals []int
+	for i := s.Low; i <= s.High; i += step {
+		vals = append(vals, i)
+	}
+	return vals
+}
+
+// Values expands every term of the field into the sorted set of values it matches. Values that stand for another, such as weekday 7 for Sunday, are folded into it.
+func (c *Catcher) Values() []int {
+	seen := map[int]bool{}
+	var vals []int
+	for i := 0; i < len(c.Spans); i++ {
+		for _, v := range c.Spans[i].Values() {
+			if same, ok := c.equal[v]; ok {
+				v = same
+			}
+			if !seen[v] {
+				seen[v] = true
+				vals = append(vals, v)
+			}
+		}
+	}
+	sort.Ints(vals)
+	return vals
+}
+
+// parseField parses a single field of a cron expression following the Vixie grammar:
+//
+//	field = term *("," term)
+//	term  = ("*" | value | value "-" value) ["/" step]
+//
+// Fields that accept the Quartz "?" placeholder may instead be written as a lone "?", and fields that accept Quartz special terms may be written as one.
+// A stepped single value such as "5/15" is read as "5-high/15". Every value must lie within the bounds of the field, and may be written
+// as one of the field's names where it has any. The error returned is positioned at the part of s that failed to parse.
+func parseField(s string, b FieldSpec) (Catcher, error) {
+	if s == "" {
+		return Catcher{}, errorf(ErrEmpty, "empty field")
+	}
+	if i := strings.Index(s, "?"); i >= 0 {
+		if !b.Any {
+			return Catcher{}, errorAt(i, 1, errorf(ErrPlaceholder, "\"?\" is not accepted in this field"))
+		}
+		if s != "?" {
+			return Catcher{}, errorAt(i, 1, errorf(ErrPlaceholder, "\"?\" must stand alone in %q", s))
+		}
+		span := Span{Low: b.Low, High: b.High, Kind: DelimAny}
+		return Catcher{Low: b.Low, High: []int{b.High}, DelimKind: DelimAny, Raw: s, Spans: []Span{span}, parsed: []Span{span},
+			equal: b.Equal, stepFrom: b.StepFrom}, nil
+	}
+	terms := strings.Split(s, ",")
+	spans := make([]Span, 0, len(terms))
+	offset := 0
+	for i := 0; i < len(terms); i++ {
+		span, special, err := parseSpecial(terms[i], b)
+		if special && err == nil && len(terms) > 1 {
+			err = errorf(ErrBadSpecial, "special term %q must stand alone", terms[i])
+		}
+		if !special {
+			span, err = parseTerm(terms[i], b)
+		}
+		if err != nil {
+			if terms[i] == "" && offset > 0 {
+				// point at the comma left without a term after it
+				return Catcher{}, errorAt(offset-1, 1, err)
+			}
+			return Catcher{}, errorAt(offset, len(terms[i]), err)
+		}
+		spans = append(spans, span)
+		offset += len(terms[i]) + 1
+	}
+	c := newCatcher(s, spans)
+	c.equal, c.stepFrom = b.Equal, b.StepFrom
+	return c, nil
+}
+
+// parseTerm parses one comma-separated term of a field into a Span. The error returned is positioned at the part of the term that failed to parse.
+func parseTerm(term string, b FieldSpec) (Span, error) {
+	if term == "" {
+		return Span{}, errorf(ErrEmpty, "empty list element")
+	}
+	base, stepStr, hasStep := strings.Cut(term, "/")
+
+	var span Span
+	switch {
+	case base == "*":
+		span = Span{Low: b.Low, High: b.High, Kind: DelimWildcard}
+	case strings.Contains(base, "-"):
+		lowStr, highStr, _ := strings.Cut(base, "-")
+		start, err := parseValue(lowStr, b)
+		if err != nil {
+			return Span{}, errorAt(0, len(lowStr), err)
+		}
+		end, err := parseValue(highStr, b)
+		if err != nil {
+			return Span{}, errorAt(len(lowStr)+1, len(highStr), err)
+		}
+		if start > end {
+			return Span{}, errorAt(0, len(base), errorf(ErrBadRange, "range start %d is greater than range end %d", start, end))
+		}
+		span = Span{Low: start, High: end, Kind: DelimRange}
+	default:
+		v, err := parseValue(base, b)
+		if err != nil {
+			return Span{}, errorAt(0, len(base), err)
+		}
+		span = Span{Low: v, High: v, Kind: DelimNone}
+		if hasStep {
+			if !b.StepFrom {
+				return Span{}, errorAt(0, len(term), errorf(ErrBadStep, "step after the single value %q, write a range such as %s-%d/%s", base, base, b.High, stepStr))
+			}
+			span.High = b.High
+		}
+	}
+
+	if hasStep {
+		stepAt := len(base) + 1
+		step, err := strconv.Atoi(stepStr)
+		if err != nil || !canBeNumber(stepStr) {
+			return Span{}, errorAt(stepAt, len(stepStr), errorf(ErrBadStep, "step %q is not a number", stepStr))
+		}
+		if step < 1 || step > b.High-b.Low+1 {
+			return Span{}, errorAt(stepAt, len(stepStr), errorf(ErrBadStep, "step %d not within acceptable bounds 1-%d", step, b.High-b.Low+1))
+		}
+		span.Step = step
+	}
+	return span, nil
+}
+
+// parseValue parses a single number or name of a field, ensuring it lies within the bounds of the field
+func parseValue(s string, b FieldSpec) (int, error) {
+	if s == "" {
+		return 0, errorf(ErrEmpty, "missing value")
+	}
+	i, ok := b.Aliases[strings.ToUpper(s)]
+	if !ok {
+		if !canBeNumber(s) {
+			if len(b.Aliases) > 0 {
+				return 0, errorf(ErrBadValue, "value %q is neither a number nor a known name", s)
+			}
+			return 0, errorf(ErrBadValue, "value %q is not a number", s)
+		}
+		i, _ = strconv.Atoi(s)
+	}
+	if i < b.Low || i > b.High {
+		return 0, errorf(ErrOutOfRange, "number %d not within acceptable bounds %d-%d", i, b.Low, b.High)
+	}
+	return i, nil
+}
+
+// newCatcher summarizes parsed spans into a Catcher, as summarize does
+func newCatcher(raw string, spans []Span) Catcher {
+	c := Catcher{Raw: raw, Spans: spans, parsed: append([]Span(nil), spans...)}
+	c.Low, c.High, c.DelimKind = summarize(spans)
+	return c
+}
+
+// summarize returns the Low, High and DelimKind of a Catcher holding spans. Single terms keep their own delimiter kind, a stepped single term
+// becomes DelimEvery with the step as its value, and lists become DelimComma holding every value they expand to in the order written.
+func summarize(spans []Span) (int, []int, int) {
+	if len(spans) == 1 {
+		span := spans[0]
+		if span.Step > 0 {
+			return span.Step, []int{span.Step}, DelimEvery
+		}
+		return span.Low, []int{span.High}, span.Kind
+	}
+
+	var vals []int
+	for i := 0; i < len(spans); i++ {
+		vals = append(vals, spans[i].Values()...)
+	}
+	if len(vals) == 0 {
+		return 0, nil, DelimNone
+	}
+	return vals[0], vals[1:], DelimComma
+}
+
+// Terms returns the terms of a field taking values from low to high. They are its Spans, unless the field was built or changed through Low, High
+// and DelimKind: when Spans is empty, or when those no longer sum up Spans while Spans has not changed since read, the terms are derived from them
+// instead. A wildcard, a step over it and the "?" placeholder then span low to high, while Quartz special terms lose their Nth and Offset. A field
+// left at its zero value is a wildcard.
+func (c *Catcher) Terms(low, high int) []Span {
+	if len(c.Spans) > 0 && !(sameSpans(c.Spans, c.parsed) && c.summaryChanged()) {
+		return c.Spans
+	}
+	if len(c.Spans) == 0 && c.Low == 0 && c.High == nil && c.DelimKind == DelimNone {
+		return []Span{{Low: low, High: high, Kind: DelimWildcard}}
+	}
+	last := c.Low
+	if len(c.High) > 0 {
+		last = c.High[0]
+	}
+	switch c.DelimKind {
+	case DelimWildcard, DelimAny:
+		return []Span{{Low: low, High: high, Kind: c.DelimKind}}
+	case DelimEvery:
+		span := Span{Low: low, High: high, Kind: DelimWildcard}
+		if c.Low > 1 {
+			span.Step = c.Low
+		}
+		return []Span{span}
+	case DelimComma:
+		spans := []Span{{Low: c.Low, High: c.Low}}
+		for _, v := range c.High {
+			spans = append(spans, Span{Low: v, High: v})
+		}
+		return spans
+	case DelimNone:
+		return []Span{{Low: c.Low, High: c.Low}}
+	}
+	return []Span{{Low: c.Low, High: last, Kind: c.DelimKind}}
+}
+
+// Resolved returns a copy of the field holding the terms Terms returns, with Low, High and DelimKind summing them up
+func (c Catcher) Resolved(low, high int) Catcher {
+	c.Spans = c.Terms(low, high)
+	c.Low, c.High, c.DelimKind = summarize(c.Spans)
+	return c
+}
+
+// summaryChanged reports whether Low, High and DelimKind no longer sum up Spans
+func (c *Catcher) summaryChanged() bool {
+	low, high, kind := summarize(c.Spans)
+	return low != c.Low || kind != c.DelimKind || !sameValues(high, c.High)
+}
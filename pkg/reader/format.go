@@ -0,0 +1,469 @@
+package reader
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// How Format writes the values of the month and day of week fields
+const (
+	// NamesKept writes values by name in fields written with names, and as numbers otherwise
+	NamesKept = iota
+	// NamesSpelled writes every value that has a name by its name, such as "MON-FRI" for "1-5"
+	NamesSpelled
+	// NamesNumbered writes every value as a number, such as "1-5" for "MON-FRI"
+	NamesNumbered
+)
+
+// Style chooses how Format writes the fields of a schedule. The zero Style writes every field as it was written, with names and special terms in upper case.
+type Style struct {
+	// Compress rewrites each field as the shortest list of ranges and steps matching the same values, such as "*/15" for "0,15,30,45". Minutes and hours
+	// keep whether they start with "*" unless the other does, as that decides how Vixie cron runs the schedule across daylight saving transitions,
+	// and so do the day fields when the other is restricted, as that decides how they are combined.
+	Compress bool
+	// Names is NamesKept, NamesSpelled or NamesNumbered
+	Names int
+}
+
+// Format writes the decoded expression as an expression of the dialect d in the Style passed in. Nicknames are written as such, and the year is written
+// only when the expression has one.
+func (d *Dialect) Format(dec *CronExpressionDecoded, style Style) string {
+	if dec.Macro != "" {
+		return dec.Macro
+	}
+	_, fields := dec.FlattenToMap()
+	var tokens []string
+	for _, spec := range d.Fields {
+		c, ok := fields[spec.Name]
+		if !ok {
+			continue
+		}
+		tokens = append(tokens, formatField(c, spec, style, d.keepsStar(dec, spec.Name)))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// FormatExpression decodes the expression passed in following the rules of the dialect d, and writes it back in the Style passed in. See Format.
+func (d *Dialect) FormatExpression(expr string, style Style) (string, error) {
+	dec, err := d.Decode(expr)
+	if err != nil {
+		return "", err
+	}
+	return d.Format(dec, style), nil
+}
+
+// FormatCrontab writes a crontab of the format kind read from r back with its schedules in the Style passed in, and its entries laid out in aligned columns:
+// each schedule field, then the user for a SystemCrontab, then the command as written. Nicknames take up the width of every schedule field.
+// Comments and environment assignments are kept as written, leading and trailing whitespace aside, and runs of blank lines are collapsed into one.
+// Lines that cannot be read as entries, and entries whose schedules are not valid in the dialect d, are kept exactly as written, so that
+// formatting never loses text nor hides a broken entry among the aligned ones.
+func FormatCrontab(r io.Reader, kind int, d *Dialect, style Style) (string, error) {
+	var rows []formatRow
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			if len(rows) > 0 && rows[len(rows)-1].text != "" {
+				rows = append(rows, formatRow{})
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			rows = append(rows, formatRow{text: line})
+			continue
+		}
+		if _, ok := parseEnvAssignment(line); ok {
+			rows = append(rows, formatRow{text: line})
+			continue
+		}
+		entry, err := parseEntry(line, kind, d)
+		if err != nil {
+			rows = append(rows, formatRow{text: raw})
+			continue
+		}
+		schedule, err := d.FormatExpression(entry.Schedule.String(), style)
+		if err != nil {
+			rows = append(rows, formatRow{text: raw})
+			continue
+		}
+		row := formatRow{text: line, user: entry.User, command: entry.Command, entry: true}
+		if isMacro(schedule) {
+			row.nickname = schedule
+		} else {
+			row.fields = strings.Fields(schedule)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	for len(rows) > 0 && !rows[len(rows)-1].entry && rows[len(rows)-1].text == "" {
+		rows = rows[:len(rows)-1]
+	}
+	return layout(rows), nil
+}
+
+// formatRow is a line of a crontab being formatted: an entry split into its columns, or any other line as text
+type formatRow struct {
+	text     string
+	entry    bool
+	fields   []string
+	nickname string
+	user     string
+	command  string
+}
+
+// layout writes the rows of a crontab, padding each column of the entries to the width of its widest cell
+func layout(rows []formatRow) string {
+	var widths []int
+	userWidth := 0
+	for _, row := range rows {
+		for i, field := range row.fields {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if len(field) > widths[i] {
+				widths[i] = len(field)
+			}
+		}
+		if len(row.user) > userWidth {
+			userWidth = len(row.user)
+		}
+	}
+	scheduleWidth := len(widths) - 1
+	for _, w := range widths {
+		scheduleWidth += w
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		if !row.entry {
+			b.WriteString(row.text + "\n")
+			continue
+		}
+		var line strings.Builder
+		if row.nickname != "" {
+			line.WriteString(pad(row.nickname, scheduleWidth))
+		}
+		for i, field := range row.fields {
+			if i > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(pad(field, widths[i]))
+		}
+		if row.user != "" {
+			line.WriteString(" " + pad(row.user, userWidth))
+		}
+		if row.command != "" {
+			line.WriteString(" " + row.command)
+		}
+		b.WriteString(strings.TrimRightFunc(line.String(), unicode.IsSpace) + "\n")
+	}
+	return b.String()
+}
+
+// pad pads s with spaces up to width
+func pad(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// keepsStar reports whether compressing the named field of the decoded expression must keep whether it starts with "*", for the schedule to keep firing
+// at the same times. See Style.
+func (d *Dialect) keepsStar(dec *CronExpressionDecoded, name string) bool {
+	switch name {
+	case Minute:
+		return !strings.HasPrefix(dec.Hour.Raw, "*")
+	case Hour:
+		return !strings.HasPrefix(dec.Minute.Raw, "*")
+	case DayOfTheMonth:
+		return d.Days == DaysEither && dec.DayOfWeek.Restricted()
+	case DayOfTheWeek:
+		return d.Days == DaysEither && dec.DayOfMonth.Restricted()
+	}
+	return false
+}
+
+// String writes the expression as it was read while neither its nickname nor its fields have changed since. A changed expression is written
+// in its canonical form: its nickname while its fields still hold the expression the nickname stands for, and otherwise its fields, each written
+// by Catcher.String, with the seconds and year only where it has them. Reading the text written with the dialect the expression was read in
+// gives back the same fields.
+func (c CronExpressionDecoded) String() string {
+	written := c.written()
+	if c.source != "" && written == c.read {
+		return c.source
+	}
+	if c.Trigger == TriggerReboot {
+		return "@reboot"
+	}
+	if expanded, ok := Macros[c.Macro]; ok && c.fields() == expanded {
+		return c.Macro
+	}
+	return c.fields()
+}
+
+// MarshalText writes the expression the way String does
+func (c CronExpressionDecoded) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// remember records the expression the fields were read from, for String to write it back while they are unchanged
+func (c *CronExpressionDecoded) remember(source string) {
+	c.source, c.read = source, c.written()
+}
+
+// written sums up the nickname, the trigger and the fields of the expression, telling whether any of them changed
+func (c *CronExpressionDecoded) written() string {
+	return c.Macro + "\x00" + strconv.Itoa(c.Trigger) + "\x00" + c.fields()
+}
+
+// fields writes the fields of the expression in order, each as Catcher.String writes it
+func (c *CronExpressionDecoded) fields() string {
+	if c.Trigger == TriggerReboot {
+		return ""
+	}
+	keys, fields := c.FlattenToMap()
+	tokens := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tokens = append(tokens, fields[key].String())
+	}
+	return strings.Join(tokens, " ")
+}
+
+// String writes the expression as its nickname while its fields still hold the expression the nickname stands for, and otherwise as its five fields
+func (c CronExpression) String() string {
+	fields := strings.Join([]string{c.Minute, c.Hour, c.DayOfMonth, c.Month, c.DayOfWeek}, " ")
+	if expanded, ok := Macros[c.Macro]; ok && (fields == expanded || expanded == "" && strings.TrimSpace(fields) == "") {
+		return c.Macro
+	}
+	if c.Macro != "" && strings.TrimSpace(fields) == "" {
+		return c.Macro
+	}
+	return fields
+}
+
+// MarshalText writes the expression the way String does
+func (c CronExpression) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// String writes the field as it was read while its terms have not changed since, and otherwise each of its terms in canonical form, such as "1-5",
+// "*/15" or "5/15" for a step from a single value, with values written as numbers. Unless the field was read in a dialect accepting them, steps
+// from a single value are written through their range instead, such as "5-59/15", which every dialect reads.
+//
+// Fields built or changed through Low, High and DelimKind are written from the terms Terms derives from them, a wildcard, a step over it and the
+// "?" placeholder being written the same whatever the bounds of the field.
+func (c Catcher) String() string {
+	if c.unchanged() {
+		return c.Raw
+	}
+	return c.format(c.Terms(0, 0))
+}
+
+// format writes terms of the field in canonical form, as String does
+func (c *Catcher) format(terms []Span) string {
+	spans := make([]Span, len(terms))
+	for i, span := range terms {
+		if span.Kind == DelimNone && span.Step > 0 && !c.stepFrom {
+			if span.High > span.Low {
+				span.Kind = DelimRange
+			} else {
+				span.Step = 0
+			}
+		}
+		spans[i] = span
+	}
+	return formatSpans(spans, FieldSpec{}, false)
+}
+
+// MarshalText writes the field the way String does
+func (c Catcher) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// unchanged reports whether the terms of the field, and Low, High and DelimKind summing them up, are those read from Raw
+func (c *Catcher) unchanged() bool {
+	return c.Raw != "" && sameSpans(c.Spans, c.parsed) && !c.summaryChanged()
+}
+
+// formatField writes a field in the Style passed in: as written, or from its terms when the style compresses it or chooses how names are written.
+// keepStar is set when compressing the field must keep whether it starts with "*".
+func formatField(c *Catcher, spec FieldSpec, style Style, keepStar bool) string {
+	if !style.Compress && style.Names == NamesKept && c.unchanged() {
+		return strings.ToUpper(c.Raw)
+	}
+	named := style.Names == NamesSpelled || (style.Names == NamesKept && writtenWithNames(c.Raw, spec))
+	spans := c.Spans
+	if style.Compress {
+		spans = compressSpans(c, spec, named, keepStar)
+	}
+	return formatSpans(spans, spec, named)
+}
+
+// writtenWithNames reports whether a field was written with any of the names of its FieldSpec
+func writtenWithNames(raw string, spec FieldSpec) bool {
+	upper := strings.ToUpper(raw)
+	for name := range spec.Aliases {
+		if strings.Contains(upper, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatSpan writes one term of a field, writing its values by name when named is set and the field has a name for them
+func formatSpan(span Span, spec FieldSpec, named bool) string {
+	value := func(v int) string {
+		if named {
+			if name, ok := nameOf(v, spec); ok {
+				return name
+			}
+		}
+		return strconv.Itoa(v)
+	}
+	var term string
+	switch span.Kind {
+	case DelimAny:
+		return "?"
+	case DelimWildcard:
+		term = "*"
+	case DelimLastWeekday:
+		return "LW"
+	case DelimLast:
+		// the last given day of the week names its day, while the last day of the month has none
+		if span.Low > 0 {
+			return value(span.Low) + "L"
+		}
+		if span.Offset > 0 {
+			return "L-" + strconv.Itoa(span.Offset)
+		}
+		return "L"
+	case DelimWeekday:
+		return strconv.Itoa(span.Low) + "W"
+	case DelimNth:
+		return value(span.Low) + "#" + strconv.Itoa(span.Nth)
+	case DelimRange:
+		term = value(span.Low) + "-" + value(span.High)
+	default:
+		term = value(span.Low)
+	}
+	if span.Step > 0 {
+		term += "/" + strconv.Itoa(span.Step)
+	}
+	return term
+}
+
+// nameOf returns the name of a value in the field, picking the first in alphabetical order where it has more than one
+func nameOf(v int, spec FieldSpec) (string, bool) {
+	var names []string
+	for name, value := range spec.Aliases {
+		if value == v {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+// compressSpans rewrites the terms of a field as the shortest list of ranges and steps matching the same values, preferring "*" or a step over it
+// where it is no longer. When keepStar is set, fields starting with "*" are only rewritten as "*" or a step over it, and fields that do not are never
+// rewritten with "*". "?" and the Quartz special terms are kept as written.
+func compressSpans(c *Catcher, spec FieldSpec, named, keepStar bool) []Span {
+	if len(c.Spans) == 0 || c.DelimKind == DelimAny || len(specialsOf(c)) > 0 {
+		return c.Spans
+	}
+	vals := c.Values()
+	star := strings.HasPrefix(c.Raw, "*") || (c.Raw == "" && c.Spans[0].Kind == DelimWildcard)
+	if (star || !keepStar) && len(vals) > 1 {
+		for step := 1; step < spec.High-spec.Low+1; step++ {
+			span := Span{Low: spec.Low, High: spec.High, Kind: DelimWildcard}
+			if step > 1 {
+				span.Step = step
+			}
+			if sameValues(foldedValues(span, spec), vals) {
+				return []Span{span}
+			}
+		}
+		if star && keepStar {
+			return c.Spans
+		}
+	}
+
+	best := runSpans(vals)
+	if stepped, ok := steppedSpan(vals, spec); ok && len(formatSpan(stepped, spec, named)) <= len(formatSpans(best, spec, named)) {
+		best = []Span{stepped}
+	}
+	return best
+}
+
+// formatSpans writes a list of terms of a field
+func formatSpans(spans []Span, spec FieldSpec, named bool) string {
+	terms := make([]string, 0, len(spans))
+	for _, span := range spans {
+		terms = append(terms, formatSpan(span, spec, named))
+	}
+	return strings.Join(terms, ",")
+}
+
+// FormatValues writes sorted values of a field as a list of numbers, joining runs of three or more consecutive values into a range, followed by
+// the Quartz special terms given, such as "1-5,10,L"
+func FormatValues(vals []int, specials ...Span) string {
+	return formatSpans(append(runSpans(vals), specials...), FieldSpec{}, false)
+}
+
+// runSpans lays out sorted values as single values, joining runs of three or more consecutive values into a range
+func runSpans(vals []int) []Span {
+	var spans []Span
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			spans = append(spans, Span{Low: vals[i], High: vals[j], Kind: DelimRange})
+		default:
+			for k := i; k <= j; k++ {
+				spans = append(spans, Span{Low: vals[k], High: vals[k], Kind: DelimNone})
+			}
+		}
+		i = j + 1
+	}
+	return spans
+}
+
+// steppedSpan returns the single stepped term matching three or more sorted values evenly spaced more than one apart: "a/n" when the step runs
+// to the end of the field and the field accepts it, and "a-b/n" otherwise
+func steppedSpan(vals []int, spec FieldSpec) (Span, bool) {
+	if len(vals) < 3 || vals[1]-vals[0] < 2 {
+		return Span{}, false
+	}
+	step := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		if vals[i]-vals[i-1] != step {
+			return Span{}, false
+		}
+	}
+	span := Span{Low: vals[0], High: spec.High, Step: step, Kind: DelimNone}
+	if spec.StepFrom && sameValues(foldedValues(span, spec), vals) {
+		return span, true
+	}
+	span = Span{Low: vals[0], High: vals[len(vals)-1], Step: step, Kind: DelimRange}
+	return span, sameValues(foldedValues(span, spec), vals)
+}
+
+// foldedValues expands a term into the sorted set of values it matches in the field, folding values that stand for another into it
+func foldedValues(span Span, spec FieldSpec) []int {
+	c := Catcher{Spans: []Span{span}, equal: spec.Equal}
+	return c.Values()
+}
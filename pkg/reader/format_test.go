@@ -0,0 +1,275 @@
+package reader
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatCase is an expression of a dialect and the expression expected of formatting it in a style
+type formatCase struct {
+	dialect  *Dialect
+	style    Style
+	expr     string
+	expected string
+}
+
+var FormatTestInputs = []formatCase{
+	{Vixie, Style{}, "0,15,30,45 * * * mon-fri", "0,15,30,45 * * * MON-FRI"},
+	{Vixie, Style{}, "@daily", "@daily"},
+	{Vixie, Style{Compress: true}, "0,15,30,45 * * * *", "*/15 * * * *"},
+	{Vixie, Style{Compress: true}, "0,15,30,45 9-17 * * *", "0-45/15 9-17 * * *"},
+	{Vixie, Style{Compress: true}, "10,25,40,55 9 * * *", "10-55/15 9 * * *"},
+	{Cronie, Style{Compress: true}, "0,15,30,45 9 * * *", "0-45/15 9 * * *"},
+	{Vixie, Style{Compress: true}, "*/1 0-23 * * *", "* * * * *"},
+	{Vixie, Style{Compress: true}, "0 9 * * 1,2,3,4,5", "0 9 * * 1-5"},
+	{Vixie, Style{Compress: true}, "0 9 * jan,feb,mar,jun *", "0 9 * JAN-MAR,JUN *"},
+	{Vixie, Style{Compress: true}, "0 0 1-31 * 1", "0 0 1-31 * 1"},
+	{Vixie, Style{Compress: true}, "0 0 * * 1-7", "0 0 * * *"},
+	{Vixie, Style{Compress: true}, "5,6 12,14,16,18 * * *", "5,6 12-18/2 * * *"},
+	{Vixie, Style{Names: NamesSpelled}, "0 9 * 1,6 1-5", "0 9 * JAN,JUN MON-FRI"},
+	{Vixie, Style{Names: NamesNumbered}, "0 9 * JAN SUN#1", ""},
+	{Vixie, Style{Names: NamesNumbered}, "0 9 * JAN MON-FRI", "0 9 * 1 1-5"},
+	{Quartz, Style{Names: NamesSpelled}, "0 0 12 ? * 2#1 2026", "0 0 12 ? * MON#1 2026"},
+	{Quartz, Style{Compress: true}, "0 0 12 L-3 * ?", "0 0 12 L-3 * ?"},
+	{Quartz, Style{Compress: true, Names: NamesSpelled}, "0 0,30 9 ? * 2,3,4,5,6", "0 0,30 9 ? * MON-FRI"},
+	{Quartz, Style{Compress: true}, "0,20,40 0,30 * ? * *", "*/20 */30 * ? * *"},
+	{Quartz, Style{Compress: true}, "0 10,25,40,55 9 ? * *", "0 10/15 9 ? * *"},
+}
+
+// TestFormat tests that expressions are written in the style asked for, and still fire at the same times
+func (c *CronTab) TestFormat() {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	for _, tc := range FormatTestInputs {
+		formatted, err := tc.dialect.FormatExpression(tc.expr, tc.style)
+		if tc.expected == "" {
+			c.Assert().Error(err, tc.expr)
+			continue
+		}
+		c.Require().NoError(err, tc.expr)
+		c.Assert().Equal(tc.expected, formatted, tc.expr)
+
+		sched, err := tc.dialect.Parse(tc.expr)
+		c.Require().NoError(err, tc.expr)
+		target, err := tc.dialect.Parse(formatted)
+		c.Require().NoError(err, formatted)
+		c.Assert().Equal(sched.NextN(from, 20), target.NextN(from, 20), tc.expr)
+	}
+}
+
+// TestFormatCrontab tests that entries are aligned in columns, while comments, assignments, unreadable lines and invalid entries are kept
+func (c *CronTab) TestFormatCrontab() {
+	text := "\n\nSHELL=/bin/sh\n  # nightly backup  \n0 2 * * *    /bin/backup   --full\n\n\n@daily /bin/rotate\n30   8 * * 1-5 /bin/report  \nnot an entry\n\n"
+	formatted, err := FormatCrontab(strings.NewReader(text), UserCrontab, Vixie, Style{})
+	c.Require().NoError(err)
+	c.Assert().Equal("SHELL=/bin/sh\n# nightly backup\n0  2 * * *   /bin/backup   --full\n\n@daily       /bin/rotate\n30 8 * * 1-5 /bin/report\nnot an entry\n", formatted)
+
+	again, err := FormatCrontab(strings.NewReader(formatted), UserCrontab, Vixie, Style{})
+	c.Require().NoError(err)
+	c.Assert().Equal(formatted, again)
+
+	formatted, err = FormatCrontab(strings.NewReader("0,30 * * * * root /bin/a\n0 0 * * 0 nobody /bin/b\n"), SystemCrontab, Vixie, Style{Compress: true})
+	c.Require().NoError(err)
+	c.Assert().Equal("*/30 * * * * root   /bin/a\n0    0 * * 0 nobody /bin/b\n", formatted)
+
+	// invalid entries are kept exactly as written, and do not widen the columns of the others
+	formatted, err = FormatCrontab(strings.NewReader("0 2 * * * /bin/a\n  */0 *  * * *   broken\n30 8 * * 1-5 /bin/b\n"), UserCrontab, Vixie, Style{})
+	c.Require().NoError(err)
+	c.Assert().Equal("0  2 * * *   /bin/a\n  */0 *  * * *   broken\n30 8 * * 1-5 /bin/b\n", formatted)
+}
+
+// randomField writes a random field of the FieldSpec passed in: a list of up to three values, ranges, wildcards and steps, with values written by
+// name now and then
+func randomField(r *rand.Rand, spec FieldSpec) string {
+	value := func() (int, string) {
+		v := spec.Low + r.Intn(spec.High-spec.Low+1)
+		if name, ok := nameOf(v, spec); ok && r.Intn(3) == 0 {
+			return v, strings.ToLower(name)
+		}
+		return v, strconv.Itoa(v)
+	}
+	step := func() string {
+		return "/" + strconv.Itoa(1+r.Intn(spec.High-spec.Low+1))
+	}
+	terms := make([]string, 1+r.Intn(3))
+	for i := range terms {
+		switch r.Intn(5) {
+		case 0:
+			terms[i] = "*"
+		case 1:
+			terms[i] = "*" + step()
+		case 2:
+			_, v := value()
+			if !spec.StepFrom {
+				v += "-" + strconv.Itoa(spec.High)
+			}
+			terms[i] = v + step()
+		default:
+			low, lowText := value()
+			high, highText := value()
+			switch {
+			case high < low:
+				terms[i] = highText + "-" + lowText
+			case high == low:
+				terms[i] = lowText
+			default:
+				terms[i] = lowText + "-" + highText
+			}
+			if r.Intn(3) == 0 && (high != low || spec.StepFrom) {
+				terms[i] += step()
+			}
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// randomExpression writes a random expression of the dialect d, its fields separated by one or two spaces. The day fields of dialects requiring
+// "?" in one of them get it, and the other is now and then a Quartz special term.
+func randomExpression(r *rand.Rand, d *Dialect) string {
+	anyField := DayOfTheMonth
+	if r.Intn(2) == 0 {
+		anyField = DayOfTheWeek
+	}
+	var b strings.Builder
+	for i, spec := range d.Fields {
+		if i >= len(d.Fields)-d.Optional && r.Intn(2) == 0 {
+			break
+		}
+		if i > 0 {
+			b.WriteString(strings.Repeat(" ", 1+r.Intn(2)))
+		}
+		switch {
+		case d.Days == DaysExclusive && spec.Name == anyField:
+			b.WriteString("?")
+		case spec.Specials&SpecialsDayOfMonth != 0 && r.Intn(4) == 0:
+			b.WriteString([]string{"L", "L-3", "LW", "15W"}[r.Intn(4)])
+		case spec.Specials&SpecialsDayOfWeek != 0 && r.Intn(4) == 0:
+			b.WriteString([]string{"L", "6L", "fri#3", "2#1"}[r.Intn(4)])
+		default:
+			b.WriteString(randomField(r, spec))
+		}
+	}
+	return b.String()
+}
+
+// sameFields asserts that two decoded expressions have the same nickname, trigger and terms in every field
+func (c *CronTab) sameFields(expected, actual *CronExpressionDecoded, msg string) {
+	c.Assert().Equal(expected.Macro, actual.Macro, msg)
+	c.Assert().Equal(expected.Trigger, actual.Trigger, msg)
+	keys, fields := expected.FlattenToMap()
+	actualKeys, actualFields := actual.FlattenToMap()
+	c.Require().Equal(keys, actualKeys, msg)
+	for _, key := range keys {
+		c.Assert().Equal(fields[key].Spans, actualFields[key].Spans, "%s: %s", msg, key)
+		c.Assert().Equal(fields[key].Values(), actualFields[key].Values(), "%s: %s", msg, key)
+	}
+}
+
+// TestRoundTrip tests that decoded expressions write back as they were read, and that changed ones write a canonical form reading back into the same fields
+func (c *CronTab) TestRoundTrip() {
+	r := rand.New(rand.NewSource(1))
+	for _, d := range []*Dialect{Vixie, Quartz, AWS} {
+		for i := 0; i < 500; i++ {
+			expr := randomExpression(r, d)
+			dec, err := d.Decode(expr)
+			c.Require().NoError(err, expr)
+			c.Require().Equal(expr, dec.String(), "%s: unchanged", d.Name)
+			text, err := dec.MarshalText()
+			c.Require().NoError(err)
+			again, err := d.Decode(string(text))
+			c.Require().NoError(err, expr)
+			c.Assert().Equal(dec, again, expr)
+
+			// change every field to the terms of another random expression, leaving Raw as read
+			other, err := d.Decode(randomExpression(r, d))
+			c.Require().NoError(err)
+			keys, fields := dec.FlattenToMap()
+			_, otherFields := other.FlattenToMap()
+			for _, key := range keys {
+				if otherField, ok := otherFields[key]; ok {
+					fields[key].Spans = otherField.Spans
+				}
+			}
+			changed := dec.String()
+			again, err = d.Decode(changed)
+			c.Require().NoError(err, "%s changed into %s", expr, changed)
+			c.sameFields(dec, again, expr+" changed into "+changed)
+			c.Assert().Equal(changed, again.String())
+		}
+	}
+
+	// change a field of a Vixie expression to a step from a single value, as Quartz reads "5/15", which Vixie cron only reads as a stepped range
+	for i := 0; i < 500; i++ {
+		expr := randomExpression(r, Vixie)
+		dec, err := Vixie.Decode(expr)
+		c.Require().NoError(err, expr)
+		keys, fields := dec.FlattenToMap()
+		key := keys[r.Intn(len(keys))]
+		spec, _ := Vixie.Spec(key)
+		low := spec.Low + r.Intn(spec.High-spec.Low)
+		step := 1 + r.Intn(spec.High-low)
+		fields[key].Spans = []Span{{Low: low, High: spec.High, Step: step, Kind: DelimNone}}
+		changed := dec.String()
+		again, err := Vixie.Decode(changed)
+		c.Require().NoError(err, "%s changed into %s", expr, changed)
+		_, againFields := again.FlattenToMap()
+		for _, k := range keys {
+			c.Assert().Equal(fields[k].Values(), againFields[k].Values(), "%s changed into %s: %s", expr, changed, k)
+		}
+		c.Assert().Equal([]Span{{Low: low, High: spec.High, Step: step, Kind: DelimRange}}, againFields[key].Spans, changed)
+	}
+}
+
+// TestString tests how changed nicknames and fields are written
+func (c *CronTab) TestString() {
+	dec, err := Vixie.Decode("@daily")
+	c.Require().NoError(err)
+	c.Assert().Equal("@daily", dec.String())
+	dec.Hour.Spans = []Span{{Low: 5, High: 5}}
+	c.Assert().Equal("0 5 * * *", dec.String())
+	dec.Hour.Spans = []Span{{Low: 0, High: 0}}
+	c.Assert().Equal("@daily", dec.String())
+	dec.Macro = ""
+	c.Assert().Equal("0 0 * * *", dec.String())
+
+	dec, err = Vixie.Decode("0  9 * * mon-fri")
+	c.Require().NoError(err)
+	c.Assert().Equal("0  9 * * mon-fri", dec.String())
+	c.Assert().Equal("mon-fri", dec.DayOfWeek.String())
+	dec.DayOfWeek.Spans = append(dec.DayOfWeek.Spans, Span{Low: 6, High: 6})
+	c.Assert().Equal("0 9 * * 1-5,6", dec.String())
+
+	// fields changed through Low, High and DelimKind, or built with them alone, are written from them
+	dec, err = Vixie.Decode("*/5 9 * * *")
+	c.Require().NoError(err)
+	dec.Minute.Low, dec.Minute.High, dec.Minute.DelimKind = 15, []int{15}, DelimNone
+	dec.Hour.Low, dec.Hour.High, dec.Hour.DelimKind = 9, []int{17}, DelimRange
+	dec.DayOfWeek.Low, dec.DayOfWeek.High, dec.DayOfWeek.DelimKind = 1, []int{3, 5}, DelimComma
+	c.Assert().Equal("15 9-17 * * 1,3,5", dec.String())
+	built := CronExpressionDecoded{Minute: Catcher{Low: 5, High: []int{5}, DelimKind: DelimNone}, Hour: Catcher{Low: 2, High: []int{2}, DelimKind: DelimEvery},
+		Month: Catcher{DelimKind: DelimWildcard}}
+	c.Assert().Equal("5 */2 * * *", built.String())
+	hour := built.Hour.Resolved(0, 6)
+	c.Assert().Equal([]int{0, 2, 4, 6}, hour.Values())
+
+	reboot, err := Vixie.Decode("@reboot")
+	c.Require().NoError(err)
+	c.Assert().Equal("@reboot", reboot.String())
+
+	expr := CronExpression{Minute: "0", Hour: "0", DayOfMonth: "*", Month: "*", DayOfWeek: "*", Macro: "@daily"}
+	c.Assert().Equal("@daily", expr.String())
+	expr.Hour = "5"
+	text, err := expr.MarshalText()
+	c.Require().NoError(err)
+	c.Assert().Equal("0 5 * * *", string(text))
+}
+
+// TestFormatValues tests that values are written with their runs joined into ranges, followed by the special terms given
+func (c *CronTab) TestFormatValues() {
+	c.Assert().Equal("1-5,10,12", FormatValues([]int{1, 2, 3, 4, 5, 10, 12}))
+	c.Assert().Equal("1,2", FormatValues([]int{1, 2}))
+	c.Assert().Equal("L-3", FormatValues(nil, Span{Kind: DelimLast, Offset: 3}))
+	c.Assert().Equal("15W,6L,2#3", FormatValues(nil, Span{Low: 15, High: 15, Kind: DelimWeekday}, Span{Low: 6, High: 6, Kind: DelimLast},
+		Span{Low: 2, High: 2, Kind: DelimNth, Nth: 3}))
+}
@@ -0,0 +1,240 @@
+package reader
+
+import (
+	"encoding/json"
+)
+
+// SchemaVersion is the version of the JSON schema the reader types marshal into, and the crontable command writes its results in. It is raised whenever
+// a property is renamed, removed or changes meaning; properties may be added without raising it.
+const SchemaVersion = 1
+
+// delimNames names the delimiter kinds in JSON
+var delimNames = map[int]string{
+	DelimNone:        "value",
+	DelimWildcard:    "wildcard",
+	DelimComma:       "list",
+	DelimRange:       "range",
+	DelimEvery:       "step",
+	DelimAny:         "any",
+	DelimLast:        "last",
+	DelimWeekday:     "weekday",
+	DelimLastWeekday: "lastWeekday",
+	DelimNth:         "nth",
+}
+
+// DelimName returns the name a delimiter kind is written as in JSON, such as "range" for DelimRange
+func DelimName(kind int) string {
+	return delimNames[kind]
+}
+
+// kindNames names the sentinel errors in JSON
+var kindNames = map[error]string{
+	ErrFieldCount:     "fieldCount",
+	ErrEmpty:          "empty",
+	ErrBadValue:       "badValue",
+	ErrOutOfRange:     "outOfRange",
+	ErrBadRange:       "badRange",
+	ErrBadStep:        "badStep",
+	ErrPlaceholder:    "placeholder",
+	ErrBadSpecial:     "badSpecial",
+	ErrUnknownMacro:   "unknownMacro",
+	ErrNoCommand:      "noCommand",
+	ErrBadUser:        "badUser",
+	ErrNoExpression:   "noExpression",
+	ErrUnknownKind:    "unknownKind",
+	ErrUnknownDialect: "unknownDialect",
+	ErrBadLocation:    "badLocation",
+	ErrUnconvertible:  "unconvertible",
+}
+
+// spanJSON is the JSON form of a Span
+type spanJSON struct {
+	Kind   string `json:"kind" yaml:"kind"`
+	Low    int    `json:"low" yaml:"low"`
+	High   int    `json:"high" yaml:"high"`
+	Step   int    `json:"step,omitempty" yaml:"step,omitempty"`
+	Nth    int    `json:"nth,omitempty" yaml:"nth,omitempty"`
+	Offset int    `json:"offset,omitempty" yaml:"offset,omitempty"`
+}
+
+// MarshalJSON writes the span as an object holding the name of its kind, its bounds, and its step and Quartz special numbers where it has them
+func (s Span) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.schema())
+}
+
+// MarshalYAML writes the span the way MarshalJSON does
+func (s Span) MarshalYAML() (interface{}, error) {
+	return s.schema(), nil
+}
+
+func (s Span) schema() spanJSON {
+	return spanJSON{Kind: DelimName(s.Kind), Low: s.Low, High: s.High, Step: s.Step, Nth: s.Nth, Offset: s.Offset}
+}
+
+// catcherJSON is the JSON form of a Catcher
+type catcherJSON struct {
+	Raw    string `json:"raw" yaml:"raw"`
+	Kind   string `json:"kind" yaml:"kind"`
+	Values []int  `json:"values" yaml:"values,flow"`
+	Terms  []Span `json:"terms" yaml:"terms"`
+}
+
+// MarshalJSON writes the field as an object holding its token as written, the name of its delimiter kind, the sorted set of values it expands to,
+// and each of its terms, all taken from Spans: the token is written from them in canonical form once they changed since read
+func (c Catcher) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.schema())
+}
+
+// MarshalYAML writes the field the way MarshalJSON does
+func (c Catcher) MarshalYAML() (interface{}, error) {
+	return c.schema(), nil
+}
+
+func (c Catcher) schema() catcherJSON {
+	vals := c.Values()
+	if vals == nil {
+		vals = []int{}
+	}
+	terms := c.Spans
+	if terms == nil {
+		terms = []Span{}
+	}
+	raw := c.Raw
+	if raw == "" || !sameSpans(c.Spans, c.parsed) {
+		raw = c.format(c.Spans)
+	}
+	_, _, kind := summarize(c.Spans)
+	return catcherJSON{Raw: raw, Kind: DelimName(kind), Values: vals, Terms: terms}
+}
+
+// fieldsJSON is the JSON form of the fields of a CronExpressionDecoded, keyed by field name
+type fieldsJSON struct {
+	Second        *Catcher `json:"second,omitempty" yaml:"second,omitempty"`
+	Minute        *Catcher `json:"minute,omitempty" yaml:"minute,omitempty"`
+	Hour          *Catcher `json:"hour,omitempty" yaml:"hour,omitempty"`
+	DayOfTheMonth *Catcher `json:"dayOfTheMonth,omitempty" yaml:"dayOfTheMonth,omitempty"`
+	Month         *Catcher `json:"month,omitempty" yaml:"month,omitempty"`
+	DayOfTheWeek  *Catcher `json:"dayOfTheWeek,omitempty" yaml:"dayOfTheWeek,omitempty"`
+	Year          *Catcher `json:"year,omitempty" yaml:"year,omitempty"`
+}
+
+// decodedJSON is the JSON form of a CronExpressionDecoded
+type decodedJSON struct {
+	Macro   string      `json:"macro,omitempty" yaml:"macro,omitempty"`
+	Trigger string      `json:"trigger" yaml:"trigger"`
+	Fields  *fieldsJSON `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// MarshalJSON writes the decoded expression as an object holding its nickname where it has one, its trigger as "time" or "reboot",
+// and its fields keyed by their names. @reboot has no fields.
+func (c CronExpressionDecoded) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.schema())
+}
+
+// MarshalYAML writes the decoded expression the way MarshalJSON does
+func (c CronExpressionDecoded) MarshalYAML() (interface{}, error) {
+	return c.schema(), nil
+}
+
+func (c CronExpressionDecoded) schema() decodedJSON {
+	if c.Trigger == TriggerReboot {
+		return decodedJSON{Macro: c.Macro, Trigger: "reboot"}
+	}
+	return decodedJSON{Macro: c.Macro, Trigger: "time", Fields: &fieldsJSON{
+		Second:        c.Second,
+		Minute:        &c.Minute,
+		Hour:          &c.Hour,
+		DayOfTheMonth: &c.DayOfMonth,
+		Month:         &c.Month,
+		DayOfTheWeek:  &c.DayOfWeek,
+		Year:          c.Year,
+	}}
+}
+
+// scheduleJSON is the JSON form of a Schedule
+type scheduleJSON struct {
+	Version     int    `json:"version" yaml:"version"`
+	Expression  string `json:"expression" yaml:"expression"`
+	Dialect     string `json:"dialect" yaml:"dialect"`
+	Location    string `json:"location,omitempty" yaml:"location,omitempty"`
+	decodedJSON `yaml:",inline"`
+}
+
+// MarshalJSON writes the schedule as an object holding the SchemaVersion, its expression as written, the name of its dialect, the name of its
+// time zone where it has one, and the properties its CronExpressionDecoded marshals into
+func (s Schedule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.schema())
+}
+
+// MarshalYAML writes the schedule the way MarshalJSON does
+func (s Schedule) MarshalYAML() (interface{}, error) {
+	return s.schema(), nil
+}
+
+func (s Schedule) schema() scheduleJSON {
+	d := s.Dialect
+	if d == nil {
+		d = Vixie
+	}
+	out := scheduleJSON{Version: SchemaVersion, Expression: s.Expr, Dialect: d.Name}
+	if s.Location != nil {
+		out.Location = s.Location.String()
+	}
+	if s.CronExpressionDecoded != nil {
+		out.decodedJSON = s.CronExpressionDecoded.schema()
+	}
+	return out
+}
+
+// diagnosticJSON is the JSON form of a Diagnostic
+type diagnosticJSON struct {
+	Field    string `json:"field,omitempty" yaml:"field,omitempty"`
+	Offset   int    `json:"offset" yaml:"offset"`
+	Length   int    `json:"length" yaml:"length"`
+	Column   int    `json:"column" yaml:"column"`
+	Token    string `json:"token" yaml:"token"`
+	Severity string `json:"severity" yaml:"severity"`
+	Message  string `json:"message" yaml:"message"`
+	Kind     string `json:"kind,omitempty" yaml:"kind,omitempty"`
+}
+
+// MarshalJSON writes the diagnostic as an object holding its position, its severity by name, its message, and the name of the sentinel error
+// classifying it, such as "outOfRange" for ErrOutOfRange
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.schema())
+}
+
+// MarshalYAML writes the diagnostic the way MarshalJSON does
+func (d Diagnostic) MarshalYAML() (interface{}, error) {
+	return d.schema(), nil
+}
+
+func (d Diagnostic) schema() diagnosticJSON {
+	return diagnosticJSON{
+		Field: d.Field, Offset: d.Offset, Length: d.Length, Column: d.Column, Token: d.Token,
+		Severity: SeverityName(d.Severity), Message: d.Message, Kind: kindNames[d.Kind],
+	}
+}
+
+// entryJSON is the JSON form of an Entry
+type entryJSON struct {
+	Line     int      `json:"line" yaml:"line"`
+	Comments []string `json:"comments,omitempty" yaml:"comments,omitempty"`
+	Schedule string   `json:"schedule" yaml:"schedule"`
+	User     string   `json:"user,omitempty" yaml:"user,omitempty"`
+	Command  string   `json:"command" yaml:"command"`
+}
+
+// MarshalJSON writes the entry as an object holding its line, the comments above it, its schedule as written, its user where it has one, and its command
+func (e Entry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.schema())
+}
+
+// MarshalYAML writes the entry the way MarshalJSON does
+func (e Entry) MarshalYAML() (interface{}, error) {
+	return e.schema(), nil
+}
+
+func (e Entry) schema() entryJSON {
+	return entryJSON{Line: e.Line, Comments: e.Comments, Schedule: e.Schedule.String(), User: e.User, Command: e.Command}
+}
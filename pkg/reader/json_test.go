@@ -0,0 +1,57 @@
+package reader
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TestScheduleJSON tests that schedules marshal into the versioned schema, with each field expanded into its values and terms
+func (c *CronTab) TestScheduleJSON() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	c.Require().NoError(err)
+	sched, err := ParseInLocation("*/20 9-17 * * MON,FRI", berlin)
+	c.Require().NoError(err)
+	out, err := json.Marshal(sched)
+	c.Require().NoError(err)
+	c.Assert().JSONEq(`{
+		"version": 1, "expression": "*/20 9-17 * * MON,FRI", "dialect": "vixie", "location": "Europe/Berlin", "trigger": "time",
+		"fields": {
+			"minute": {"raw": "*/20", "kind": "step", "values": [0, 20, 40], "terms": [{"kind": "wildcard", "low": 0, "high": 59, "step": 20}]},
+			"hour": {"raw": "9-17", "kind": "range", "values": [9, 10, 11, 12, 13, 14, 15, 16, 17], "terms": [{"kind": "range", "low": 9, "high": 17}]},
+			"dayOfTheMonth": {"raw": "*", "kind": "wildcard", "values": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31],
+				"terms": [{"kind": "wildcard", "low": 1, "high": 31}]},
+			"month": {"raw": "*", "kind": "wildcard", "values": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12], "terms": [{"kind": "wildcard", "low": 1, "high": 12}]},
+			"dayOfTheWeek": {"raw": "MON,FRI", "kind": "list", "values": [1, 5], "terms": [{"kind": "value", "low": 1, "high": 1}, {"kind": "value", "low": 5, "high": 5}]}
+		}
+	}`, string(out))
+
+	sched, err = Quartz.Parse("0 0 9 ? * 2#1")
+	c.Require().NoError(err)
+	out, err = json.Marshal(sched.DayOfWeek)
+	c.Require().NoError(err)
+	c.Assert().JSONEq(`{"raw": "2#1", "kind": "nth", "values": [], "terms": [{"kind": "nth", "low": 2, "high": 2, "nth": 1}]}`, string(out))
+
+	// changed terms write a raw token and a kind agreeing with their values
+	sched, err = Parse("0 9 * * *")
+	c.Require().NoError(err)
+	sched.Minute.Spans = []Span{{Low: 0, High: 59, Step: 15, Kind: DelimWildcard}}
+	out, err = json.Marshal(sched.Minute)
+	c.Require().NoError(err)
+	c.Assert().JSONEq(`{"raw": "*/15", "kind": "step", "values": [0, 15, 30, 45], "terms": [{"kind": "wildcard", "low": 0, "high": 59, "step": 15}]}`, string(out))
+
+	sched, err = Parse("@reboot")
+	c.Require().NoError(err)
+	out, err = json.Marshal(sched)
+	c.Require().NoError(err)
+	c.Assert().JSONEq(`{"version": 1, "expression": "@reboot", "dialect": "vixie", "macro": "@reboot", "trigger": "reboot"}`, string(out))
+}
+
+// TestDiagnosticJSON tests that diagnostics marshal with their severity and the sentinel error classifying them by name
+func (c *CronTab) TestDiagnosticJSON() {
+	diags := Vixie.Diagnose("0 24 * * *")
+	c.Require().Len(diags, 1)
+	out, err := json.Marshal(diags[0])
+	c.Require().NoError(err)
+	c.Assert().JSONEq(`{"field": "hour", "offset": 2, "length": 2, "column": 3, "token": "24", "severity": "error",
+		"message": "number 24 not within acceptable bounds 0-23", "kind": "outOfRange"}`, string(out))
+}
@@ -0,0 +1,39 @@
+package reader
+
+import (
+	"strings"
+)
+
+const (
+	// TriggerTime marks a schedule that fires on the times described by its fields
+	TriggerTime = iota
+	// TriggerReboot marks a schedule that fires once when the cron daemon starts, and has no time fields
+	TriggerReboot
+)
+
+// Macros maps the nicknames accepted in place of a five field cron expression to the expression they stand for.
+// @reboot has no equivalent expression and maps to an empty string.
+var Macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * SUN",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+	"@reboot":   "",
+}
+
+// isMacro reports whether the cron expression is written as a nickname rather than as fields
+func isMacro(expr string) bool {
+	return strings.HasPrefix(strings.TrimSpace(expr), "@")
+}
+
+// expandMacro resolves a nickname into the cron expression it stands for in macros. It errors when the nickname is unknown.
+func expandMacro(expr string, macros map[string]string) (string, error) {
+	expanded, ok := macros[strings.TrimSpace(expr)]
+	if !ok {
+		return "", errorf(ErrUnknownMacro, "unknown nickname %q", strings.TrimSpace(expr))
+	}
+	return expanded, nil
+}
@@ -0,0 +1,326 @@
+package reader
+
+import (
+	"time"
+)
+
+// searchYears bounds how far Next and Prev look for a firing time. The Gregorian calendar repeats every 400 years, so a schedule that fires at all
+// fires within that many years of any time.
+const searchYears = 400
+
+// Next returns the first time after the time passed in that the schedule fires at, in the schedule's Location, or in the location of the time passed in
+// when the schedule has none. Days follow the rules of the schedule's dialect: under Vixie's rule a schedule restricting both day fields fires on days
+// matching either, while a day field starting with "*" leaves the other to decide. Daylight saving transitions follow Vixie cron and cronie:
+//
+//   - a schedule with "*" in its hour or minute field runs by the new time, so it skips wall clock times that do not exist, and fires at
+//     wall clock times that happen twice each time they happen
+//   - any other schedule fires at the first instant after a transition for the wall clock times the transition skips, and only the first time
+//     at wall clock times that happen twice
+//
+// It returns the zero time for @reboot, and for schedules that never fire, such as "0 0 30 2 *".
+// Next and Prev compile the schedule on every call; searching many times is cheaper through Compile or Iterate.
+func (s *Schedule) Next(after time.Time) time.Time {
+	return s.Compile().Next(after)
+}
+
+// Prev returns the last time before the time passed in that the schedule fired at. See Next.
+func (s *Schedule) Prev(before time.Time) time.Time {
+	return s.Compile().Prev(before)
+}
+
+// NextN returns the next n times the schedule fires at after the time passed in, in order. It returns fewer when the schedule stops firing.
+func (s *Schedule) NextN(after time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	it := s.Iterate(after)
+	for len(times) < n {
+		t := it.Next()
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// Iterator walks the times a schedule fires at, in order. Each call to Next returns the time following the one before it.
+type Iterator struct {
+	sched *Compiled
+	at    time.Time
+	done  bool
+}
+
+// Iterate returns an Iterator over the times the schedule fires at after the time passed in, compiling the schedule once for all of them
+func (s *Schedule) Iterate(after time.Time) *Iterator {
+	return s.Compile().Iterate(after)
+}
+
+// Iterate returns an Iterator over the times the schedule fires at after the time passed in
+func (c *Compiled) Iterate(after time.Time) *Iterator {
+	return &Iterator{sched: c, at: after}
+}
+
+// Next returns the next time the schedule fires at, or the zero time once it stops firing
+func (it *Iterator) Next() time.Time {
+	if it.done {
+		return time.Time{}
+	}
+	next := it.sched.Next(it.at)
+	if next.IsZero() {
+		it.done = true
+		return next
+	}
+	it.at = next
+	return next
+}
+
+// Next returns the first time after the time passed in that the schedule fires at. See Schedule.Next. Rather than stepping minute by minute,
+// the search skips whole years and months the schedule does not fire in, and jumps to the next set bit of each time of day field.
+func (c *Compiled) Next(after time.Time) time.Time {
+	if c.Reboot {
+		return time.Time{}
+	}
+	loc := c.location(after)
+	from := after.In(loc)
+	folded := foldStart(from)
+	if folded.Equal(from) {
+		return c.next(after, from, loc, time.Time{})
+	}
+	// within the first pass over wall clock times happening twice, the rest of the first pass and the second pass both lie ahead,
+	// so the earliest of the firing times left in the first pass and those from the transition on is kept
+	next := c.next(after, from, loc, folded)
+	if t := c.next(after, folded, loc, time.Time{}); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+		next = t
+	}
+	return next
+}
+
+// next returns the first time after the time passed in that the schedule fires at, searching the wall clock times from those of from on.
+// Unless limit is zero, only times before it are returned.
+func (c *Compiled) next(after, from time.Time, loc *time.Location, limit time.Time) time.Time {
+	clock := c.clockOf(from)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(searchYears, 0, 0)
+	for day.Before(end) {
+		switch {
+		case !c.hasYear(day.Year()):
+			day = time.Date(day.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		case !has(c.Month, int(day.Month())):
+			day = time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		default:
+			if c.matchesDay(day) {
+				for found, ok := c.seek(clock, 1); ok; found, ok = c.seek(c.step(found, 1), 1) {
+					instants, n := c.instants(day, found, loc)
+					for i := 0; i < n; i++ {
+						if !limit.IsZero() && !instants[i].Before(limit) {
+							if i == 0 {
+								return time.Time{}
+							}
+							continue
+						}
+						if instants[i].After(after) {
+							return instants[i]
+						}
+					}
+				}
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+		clock = [3]int{}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last time before the time passed in that the schedule fired at. See Schedule.Next.
+func (c *Compiled) Prev(before time.Time) time.Time {
+	if c.Reboot {
+		return time.Time{}
+	}
+	loc := c.location(before)
+	from := before.In(loc)
+	folded := foldEnd(from)
+	if folded.Equal(from) {
+		return c.prev(before, from, loc, time.Time{})
+	}
+	// within the second pass over wall clock times happening twice, the start of the second pass and the first pass both lie behind,
+	// so the latest of the firing times earlier in the second pass and those before the transition is kept
+	prev := c.prev(before, from, loc, folded)
+	if t := c.prev(before, folded, loc, time.Time{}); !t.IsZero() && (prev.IsZero() || t.After(prev)) {
+		prev = t
+	}
+	return prev
+}
+
+// prev returns the last time before the time passed in that the schedule fired at, searching the wall clock times from those of from back.
+// Unless limit is zero, only times after it are returned.
+func (c *Compiled) prev(before, from time.Time, loc *time.Location, limit time.Time) time.Time {
+	clock := c.clockOf(from)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(-searchYears, 0, 0)
+	for day.After(end) {
+		switch {
+		case !c.hasYear(day.Year()):
+			day = time.Date(day.Year(), time.January, 0, 0, 0, 0, 0, time.UTC)
+		case !has(c.Month, int(day.Month())):
+			day = time.Date(day.Year(), day.Month(), 0, 0, 0, 0, 0, time.UTC)
+		default:
+			if c.matchesDay(day) {
+				for found, ok := c.seek(clock, -1); ok; found, ok = c.seek(c.step(found, -1), -1) {
+					instants, n := c.instants(day, found, loc)
+					for i := n - 1; i >= 0; i-- {
+						if !limit.IsZero() && !instants[i].After(limit) {
+							if i == n-1 {
+								return time.Time{}
+							}
+							continue
+						}
+						if instants[i].Before(before) {
+							return instants[i]
+						}
+					}
+				}
+			}
+			day = day.AddDate(0, 0, -1)
+		}
+		clock = [3]int{23, 59, 59}
+	}
+	return time.Time{}
+}
+
+// location returns the location the schedule fires in: its own Location, or the location of the time passed in when it has none
+func (c *Compiled) location(t time.Time) *time.Location {
+	if c.Location != nil {
+		return c.Location
+	}
+	return t.Location()
+}
+
+// units returns how many time of day fields the schedule has: the hour and minute, and the second where it has a seconds field
+func (c *Compiled) units() int {
+	if c.Seconds {
+		return 3
+	}
+	return 2
+}
+
+// clockOf returns the hour, minute and second of t
+func (c *Compiled) clockOf(t time.Time) [3]int {
+	return [3]int{t.Hour(), t.Minute(), t.Second()}
+}
+
+// step returns the time of day one unit of the schedule after the one passed in when dir is 1, or one unit before it when dir is -1.
+// Values past the end of a field are left for seek to carry into the field above.
+func (c *Compiled) step(clock [3]int, dir int) [3]int {
+	clock[c.units()-1] += dir
+	return clock
+}
+
+// seek searches the time of day fields, from the hour down, for the nearest time of day the schedule fires at not before at when dir is 1, or not after it
+// when dir is -1, reporting false when there is none that day. Each field jumps straight to its next set bit; a field with none left carries into the field above.
+func (c *Compiled) seek(at [3]int, dir int) ([3]int, bool) {
+	fields := [3]uint64{c.Hour, c.Minute, c.Second}
+	n := c.units()
+	edge := 0
+	if dir < 0 {
+		edge = 63
+	}
+	for i := 0; i < n; {
+		v := nextBit(fields[i], at[i])
+		if dir < 0 {
+			v = prevBit(fields[i], at[i])
+		}
+		switch {
+		case v < 0:
+			if i == 0 {
+				return at, false
+			}
+			i--
+			at[i] += dir
+			for j := i + 1; j < n; j++ {
+				at[j] = edge
+			}
+		case v != at[i]:
+			at[i] = v
+			for j := i + 1; j < n; j++ {
+				at[j] = edge
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	if !c.Seconds {
+		at[2] = 0
+	}
+	return at, true
+}
+
+// instants returns the instants the schedule fires at for the time of day passed in on the day passed in, in loc, along with how many there are.
+// It is a single instant, unless a daylight saving transition skips the time of day or makes it happen twice; see Schedule.Next for how those are treated.
+func (c *Compiled) instants(day time.Time, clock [3]int, loc *time.Location) ([2]time.Time, int) {
+	var found [2]time.Time
+	wall := time.Date(day.Year(), day.Month(), day.Day(), clock[0], clock[1], clock[2], 0, time.UTC)
+	t := time.Date(day.Year(), day.Month(), day.Day(), clock[0], clock[1], clock[2], 0, loc)
+
+	if !wallOf(t).Equal(wall) {
+		// the time of day falls in a gap skipped by moving the clocks forward
+		if c.Wildcard {
+			return found, 0
+		}
+		start, end := t.ZoneBounds()
+		found[0] = end
+		if wallOf(t).After(wall) {
+			found[0] = start
+		}
+		return found, 1
+	}
+
+	found[0] = t
+	n := 1
+	start, end := t.ZoneBounds()
+	if !start.IsZero() {
+		if earlier := t.Add(-shift(start)); earlier.Before(t) && wallOf(earlier).Equal(wall) {
+			found[0], found[1], n = earlier, t, 2
+		}
+	}
+	if !end.IsZero() && n == 1 {
+		if later := t.Add(shift(end)); later.After(t) && wallOf(later).Equal(wall) {
+			found[1], n = later, 2
+		}
+	}
+	if !c.Wildcard {
+		n = 1
+	}
+	return found, n
+}
+
+// wallOf returns the wall clock time of t as a time in UTC, so that wall clock times of different offsets can be compared
+func wallOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// shift returns how far the clocks move back at the transition starting at the instant passed in, or a negative duration when they move forward
+func shift(transition time.Time) time.Duration {
+	_, before := transition.Add(-time.Nanosecond).Zone()
+	_, after := transition.Zone()
+	return time.Duration(before-after) * time.Second
+}
+
+// foldStart returns the instant to search forward from for the firing times after t in the second pass. When t falls in the first pass over wall clock
+// times that the clocks moving back make happen twice, the second pass over earlier wall clock times still lies ahead, so that search starts from the transition.
+func foldStart(t time.Time) time.Time {
+	_, end := t.ZoneBounds()
+	if back := shift(end); !end.IsZero() && back > 0 && !t.Before(end.Add(-back)) {
+		return end
+	}
+	return t
+}
+
+// foldEnd returns the instant to search back from for the firing times before t in the first pass. When t falls in the second pass over wall clock
+// times that the clocks moving back make happen twice, the first pass over later wall clock times lies behind, so that search starts from just before the transition.
+func foldEnd(t time.Time) time.Time {
+	start, _ := t.ZoneBounds()
+	if back := shift(start); !start.IsZero() && back > 0 && t.Before(start.Add(back)) {
+		return start.Add(-time.Nanosecond)
+	}
+	return t
+}
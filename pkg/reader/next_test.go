@@ -0,0 +1,156 @@
+package reader
+
+import (
+	"time"
+	_ "time/tzdata"
+)
+
+// nextCase is a schedule along with the time to search from and the time it is expected to fire at next
+type nextCase struct {
+	dialect  *Dialect
+	expr     string
+	from     string
+	expected string
+}
+
+// NextTestInputs covers month lengths, leap years, the Vixie day rule and the Quartz special terms. Times are written in RFC 3339, in UTC.
+var NextTestInputs = []nextCase{
+	{Vixie, "*/15 9-17 * * MON-FRI", "2026-10-16T17:45:00Z", "2026-10-19T09:00:00Z"}, // Friday evening to Monday morning
+	{Vixie, "0 0 31 * *", "2026-04-01T00:00:00Z", "2026-05-31T00:00:00Z"},            // April has no 31st
+	{Vixie, "0 0 29 2 *", "2026-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},            // leap day
+	{Vixie, "0 0 29 2 *", "2096-03-01T00:00:00Z", "2104-02-29T00:00:00Z"},            // 2100 is not a leap year
+	{Vixie, "0 9 1 * MON", "2026-10-02T00:00:00Z", "2026-10-05T09:00:00Z"},           // either day field when both are restricted
+	{Vixie, "0 9 */2 * MON", "2026-10-02T00:00:00Z", "2026-10-05T09:00:00Z"},         // both day fields when one starts with "*"
+	{Vixie, "0 9 */2 * MON", "2026-10-05T10:00:00Z", "2026-10-19T09:00:00Z"},         // Mondays falling on odd days
+	{Vixie, "30 4 * * 7", "2026-10-17T00:00:00Z", "2026-10-18T04:30:00Z"},            // weekday 7 is Sunday
+	{Vixie, "0 0 1 1 *", "2026-01-01T00:00:00Z", "2027-01-01T00:00:00Z"},             // strictly after
+	{Vixie, "@hourly", "2026-12-31T23:00:30Z", "2027-01-01T00:00:00Z"},               // across the year
+	{Quartz, "30 0/20 * * * ?", "2026-10-17T10:40:30Z", "2026-10-17T11:00:30Z"},      // seconds
+	{Quartz, "0 0 12 L * ?", "2028-02-01T00:00:00Z", "2028-02-29T12:00:00Z"},         // last day of a leap February
+	{Quartz, "0 0 12 L-2 * ?", "2026-10-01T00:00:00Z", "2026-10-29T12:00:00Z"},       // two days before the last
+	{Quartz, "0 0 12 LW * ?", "2026-05-01T00:00:00Z", "2026-05-29T12:00:00Z"},        // May 31 2026 is a Sunday
+	{Quartz, "0 0 12 1W * ?", "2026-08-01T00:00:00Z", "2026-08-03T12:00:00Z"},        // August 1 2026 is a Saturday
+	{Quartz, "0 0 12 15W * ?", "2026-11-01T00:00:00Z", "2026-11-16T12:00:00Z"},       // November 15 2026 is a Sunday
+	{Quartz, "0 0 12 ? * 6L", "2026-10-01T00:00:00Z", "2026-10-30T12:00:00Z"},        // last Friday
+	{Quartz, "0 0 12 ? * 2#1", "2026-10-06T00:00:00Z", "2026-11-02T12:00:00Z"},       // first Monday
+	{Quartz, "0 0 12 ? * L", "2026-10-17T13:00:00Z", "2026-10-24T12:00:00Z"},         // Saturday
+	{Quartz, "0 0 12 * * ? 2030", "2026-10-17T00:00:00Z", "2030-01-01T12:00:00Z"},    // year
+	{AWS, "0 12 ? * MON-FRI *", "2026-10-17T00:00:00Z", "2026-10-19T12:00:00Z"},      // AWS weekdays count from Sunday as 1
+	{Kubernetes, "0 12 ? * 0", "2026-10-17T00:00:00Z", "2026-10-18T12:00:00Z"},       // "?" leaves the other day field to decide
+}
+
+// TestNext tests that Next finds the first firing time after the time passed in, and Prev finds it again from just after it
+func (c *CronTab) TestNext() {
+	for _, tc := range NextTestInputs {
+		sched, err := tc.dialect.Parse(tc.expr)
+		c.Require().NoError(err, tc.expr)
+		from, _ := time.Parse(time.RFC3339, tc.from)
+		expected, _ := time.Parse(time.RFC3339, tc.expected)
+
+		next := sched.Next(from)
+		c.Assert().Equal(expected, next, "%s after %s", tc.expr, tc.from)
+		c.Assert().Equal(expected, sched.Prev(next.Add(time.Nanosecond)), "%s before %s", tc.expr, next)
+		c.Assert().True(sched.Prev(next).Before(next), "%s before %s", tc.expr, next)
+	}
+}
+
+// TestNextNever tests that schedules that never fire, and @reboot, return the zero time
+func (c *CronTab) TestNextNever() {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"0 0 30 2 *", "@reboot"} {
+		sched, err := Parse(expr)
+		c.Require().NoError(err, expr)
+		c.Assert().True(sched.Next(from).IsZero(), expr)
+		c.Assert().True(sched.Prev(from).IsZero(), expr)
+		c.Assert().Empty(sched.NextN(from, 3), expr)
+	}
+}
+
+// TestNextN tests that NextN and the Iterator walk firing times in order
+func (c *CronTab) TestNextN() {
+	sched, err := Parse("0 0 L * *")
+	c.Require().Error(err, "L is a Quartz term")
+
+	sched, err = Parse("0 12 28-31 2 *")
+	c.Require().NoError(err)
+	from := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert().Equal([]time.Time{
+		time.Date(2027, 2, 28, 12, 0, 0, 0, time.UTC),
+		time.Date(2028, 2, 28, 12, 0, 0, 0, time.UTC),
+		time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC),
+	}, sched.NextN(from, 3))
+
+	local := time.FixedZone("UTC+2", 2*60*60)
+	it := sched.Iterate(from.In(local))
+	c.Assert().Equal(time.Date(2027, 2, 28, 12, 0, 0, 0, local), it.Next(), "times are in the location passed in")
+}
+
+// dstCase is a schedule along with the time to search from and the times it is expected to fire at next, or fired at last when prev is set
+type dstCase struct {
+	expr     string
+	from     time.Time
+	expected []time.Time
+	prev     bool
+}
+
+// TestNextDST tests the firing times around the 2026 daylight saving transitions of New York, where 02:00 to 03:00 is skipped on March 8
+// and 01:00 to 02:00 happens twice on November 1
+func (c *CronTab) TestNextDST() {
+	ny, err := time.LoadLocation("America/New_York")
+	c.Require().NoError(err)
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+	at := func(month time.Month, day, hour, min int, zone *time.Location) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, zone)
+	}
+
+	cases := []dstCase{
+		// fixed times skipped by the clocks moving forward fire at the transition
+		{"30 2 * * *", at(3, 8, 0, 0, est), []time.Time{at(3, 8, 3, 0, edt), at(3, 9, 2, 30, edt)}, false},
+		{"15,45 2 * * *", at(3, 8, 0, 0, est), []time.Time{at(3, 8, 3, 0, edt), at(3, 9, 2, 15, edt)}, false},
+		// wildcard schedules run by the new time
+		{"30 * * * *", at(3, 8, 1, 45, est), []time.Time{at(3, 8, 3, 30, edt)}, false},
+		// fixed times happening twice fire once, at the first
+		{"30 1 * * *", at(11, 1, 0, 0, edt), []time.Time{at(11, 1, 1, 30, edt), at(11, 2, 1, 30, est)}, false},
+		// wildcard schedules fire at both
+		{"30 * * * *", at(11, 1, 1, 0, edt), []time.Time{at(11, 1, 1, 30, edt), at(11, 1, 1, 30, est), at(11, 1, 2, 30, est)}, false},
+		{"*/20 * * * *", at(11, 1, 1, 50, edt), []time.Time{at(11, 1, 1, 0, est), at(11, 1, 1, 20, est)}, false},
+		// including those left in the first pass when starting early in it
+		{"*/10 * * * *", at(11, 1, 1, 10, edt), []time.Time{at(11, 1, 1, 20, edt), at(11, 1, 1, 30, edt)}, false},
+		{"*/20 * * * *", at(11, 1, 1, 30, edt), []time.Time{at(11, 1, 1, 40, edt), at(11, 1, 1, 0, est), at(11, 1, 1, 20, est), at(11, 1, 1, 40, est), at(11, 1, 2, 0, est)}, false},
+		{"* * * * *", at(11, 1, 1, 0, edt), []time.Time{at(11, 1, 1, 1, edt), at(11, 1, 1, 2, edt)}, false},
+		{"* * * * *", at(11, 1, 1, 58, edt), []time.Time{at(11, 1, 1, 59, edt), at(11, 1, 1, 0, est), at(11, 1, 1, 1, est)}, false},
+		{"30 1 * * *", at(11, 1, 1, 10, edt), []time.Time{at(11, 1, 1, 30, edt), at(11, 2, 1, 30, est)}, false},
+		// and backwards
+		{"30 1 * * *", at(11, 1, 1, 10, est), []time.Time{at(11, 1, 1, 30, edt)}, true},
+		{"30 * * * *", at(11, 1, 1, 10, est), []time.Time{at(11, 1, 1, 30, edt)}, true},
+		{"30 * * * *", at(11, 1, 1, 40, est), []time.Time{at(11, 1, 1, 30, est)}, true},
+		{"*/10 * * * *", at(11, 1, 1, 35, est), []time.Time{at(11, 1, 1, 30, est), at(11, 1, 1, 20, est)}, true},
+		{"* * * * *", at(11, 1, 1, 1, est), []time.Time{at(11, 1, 1, 0, est), at(11, 1, 1, 59, edt)}, true},
+		{"30 2 * * *", at(3, 8, 4, 0, edt), []time.Time{at(3, 8, 3, 0, edt)}, true},
+	}
+	for _, tc := range cases {
+		sched, err := ParseInLocation(tc.expr, ny)
+		c.Require().NoError(err, tc.expr)
+		from := tc.from
+		for _, expected := range tc.expected {
+			next := sched.Next(from)
+			if tc.prev {
+				next = sched.Prev(from)
+			}
+			c.Assert().True(expected.Equal(next), "%s from %s: expected %s, got %s", tc.expr, from, expected, next)
+			c.Assert().Equal(ny, next.Location(), tc.expr)
+			from = next
+		}
+	}
+}
+
+// TestParseInLocation tests that schedules with a Location fire at the wall clock times of that location
+func (c *CronTab) TestParseInLocation() {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	c.Require().NoError(err)
+	sched, err := ParseInLocation("0 9 * * *", tokyo)
+	c.Require().NoError(err)
+	next := sched.Next(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	c.Assert().True(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC).Equal(next), next.String())
+}
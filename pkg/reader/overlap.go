@@ -0,0 +1,117 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Collision is a minute in which two or more schedules fire
+type Collision struct {
+	// At is the start of the minute
+	At time.Time
+	// Schedules holds the indexes of the schedules firing in the minute, in order
+	Schedules []int
+}
+
+// Concurrency returns how many schedules fire in the minute
+func (c Collision) Concurrency() int {
+	return len(c.Schedules)
+}
+
+// OverlapGroup gathers the minutes in which the same schedules fire together
+type OverlapGroup struct {
+	// Schedules holds the indexes of the schedules firing together, in order
+	Schedules []int
+	// Times holds the start of every minute they fire together in, in order
+	Times []time.Time
+}
+
+// OverlapReport is the outcome of looking for schedules firing together within a horizon
+type OverlapReport struct {
+	From  time.Time
+	Until time.Time
+	// Collisions lists every minute in which two or more schedules fire, in order, each with the number of schedules firing in it
+	Collisions []Collision
+	// Groups gathers the collisions by the schedules firing together: the most schedules first, then the most minutes, then the earliest
+	Groups []OverlapGroup
+	// Peak is the most schedules firing within a single minute, and PeakAt the first minute in which as many do. Peak is 1 when no schedules collide,
+	// and 0 when none fire at all.
+	Peak   int
+	PeakAt time.Time
+}
+
+// Overlaps looks for the minutes from from up to until in which two or more of the schedules passed in fire, to find jobs that compete for the
+// machine they run on. Schedules are told apart by their index in the list, and nil schedules are skipped. A schedule firing several times within
+// a minute, as schedules with a seconds field can, counts once in it. Every minute each schedule fires in is visited, so the horizon should stay
+// within what the schedules can be listed over, such as a day or a week.
+func Overlaps(scheds []*Schedule, from, until time.Time) *OverlapReport {
+	report := &OverlapReport{From: from, Until: until}
+	minutes := map[int64][]int{}
+	for i, sched := range scheds {
+		if sched == nil {
+			continue
+		}
+		c := sched.Compile()
+		for t := c.Next(from.Add(-time.Nanosecond)); !t.IsZero() && !t.After(until); t = c.Next(t.Truncate(time.Minute).Add(time.Minute - time.Nanosecond)) {
+			key := t.Truncate(time.Minute).Unix()
+			minutes[key] = append(minutes[key], i)
+		}
+	}
+
+	keys := make([]int64, 0, len(minutes))
+	for key := range minutes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	groups := map[string]*OverlapGroup{}
+	for _, key := range keys {
+		at := time.Unix(key, 0).In(from.Location())
+		firing := minutes[key]
+		if len(firing) > report.Peak {
+			report.Peak, report.PeakAt = len(firing), at
+		}
+		if len(firing) < 2 {
+			continue
+		}
+		report.Collisions = append(report.Collisions, Collision{At: at, Schedules: firing})
+		id := fmt.Sprint(firing)
+		if _, ok := groups[id]; !ok {
+			groups[id] = &OverlapGroup{Schedules: firing}
+		}
+		groups[id].Times = append(groups[id].Times, at)
+	}
+
+	for _, g := range groups {
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		switch {
+		case len(a.Schedules) != len(b.Schedules):
+			return len(a.Schedules) > len(b.Schedules)
+		case len(a.Times) != len(b.Times):
+			return len(a.Times) > len(b.Times)
+		}
+		return a.Times[0].Before(b.Times[0])
+	})
+	return report
+}
+
+// Overlaps looks for the minutes from from up to until in which two or more entries of the crontab fire, each in the time zone set for it.
+// Schedules are told apart by the index of their entry in Entries. Entries whose schedule cannot be parsed are left out, and reported in the error
+// returned, each prefixed with its line number, alongside the report on the others. See the Overlaps function.
+func (c *Crontab) Overlaps(from, until time.Time) (*OverlapReport, error) {
+	scheds := make([]*Schedule, len(c.Entries))
+	var errs []error
+	for i := range c.Entries {
+		sched, err := c.Schedule(c.Entries[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", c.Entries[i].Line, err))
+			continue
+		}
+		scheds[i] = sched
+	}
+	return Overlaps(scheds, from, until), errors.Join(errs...)
+}
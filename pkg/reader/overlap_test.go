@@ -0,0 +1,50 @@
+package reader
+
+import (
+	"strings"
+	"time"
+)
+
+// TestOverlaps tests that minutes in which schedules fire together are found, grouped by the schedules colliding, and counted towards the peak
+func (c *CronTab) TestOverlaps() {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	var scheds []*Schedule
+	for _, expr := range []string{"0 0 * * *", "@daily", "*/30 * * * *", "0 12 * * *", "@reboot"} {
+		sched, err := Parse(expr)
+		c.Require().NoError(err, expr)
+		scheds = append(scheds, sched)
+	}
+	quartz, err := Quartz.Parse("*/10 0 0 * * ?")
+	c.Require().NoError(err)
+	scheds = append(scheds, quartz, nil)
+
+	report := Overlaps(scheds, from, from.Add(24*time.Hour))
+	midnight := from.Add(24 * time.Hour)
+	noon := from.Add(12 * time.Hour)
+	// the window holds both its ends, so the schedules firing at midnight collide at from as well as a day later
+	c.Assert().Equal([]Collision{{At: from, Schedules: []int{0, 1, 2, 5}}, {At: noon, Schedules: []int{2, 3}}, {At: midnight, Schedules: []int{0, 1, 2, 5}}},
+		report.Collisions)
+	c.Assert().Equal(4, report.Collisions[2].Concurrency())
+	c.Assert().Equal([]OverlapGroup{{Schedules: []int{0, 1, 2, 5}, Times: []time.Time{from, midnight}}, {Schedules: []int{2, 3}, Times: []time.Time{noon}}},
+		report.Groups)
+	c.Assert().Equal(4, report.Peak)
+	c.Assert().Equal(from, report.PeakAt)
+
+	report = Overlaps(scheds[3:4], from, from.Add(24*time.Hour))
+	c.Assert().Empty(report.Collisions)
+	c.Assert().Equal(1, report.Peak)
+	c.Assert().Equal(0, Overlaps(scheds[4:5], from, from.Add(24*time.Hour)).Peak)
+}
+
+// TestCrontabOverlaps tests that the entries of a crontab are told apart by their index, each firing in the time zone set for it
+func (c *CronTab) TestCrontabOverlaps() {
+	tab, err := ParseCrontab(strings.NewReader("0 9 * * * /bin/a\n0 9 * * * /bin/b\nCRON_TZ=Europe/Berlin\n0 11 * * * /bin/c\n0 24 * * * /bin/d\n"))
+	c.Require().NoError(err)
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	report, err := tab.Overlaps(from, from.Add(24*time.Hour))
+	c.Assert().ErrorContains(err, "line 5")
+	c.Require().Len(report.Groups, 1)
+	// 11:00 in Berlin is 09:00 UTC during summer time
+	c.Assert().Equal([]int{0, 1, 2}, report.Groups[0].Schedules)
+	c.Assert().Equal(3, report.Peak)
+}
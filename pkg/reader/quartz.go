@@ -0,0 +1,35 @@
+package reader
+
+const (
+	Second = "second"
+	Year   = "year"
+)
+
+var (
+	// QuartzDayNames maps the names accepted in the day of the week field of a Quartz expression to the day they stand for. Quartz counts days from Sunday.
+	QuartzDayNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// ValidateQuartz validates a CronRead value as a Quartz expression. See ValidateQuartzExpression.
+func (cr *CronRead) ValidateQuartz() (bool, error) {
+	return ValidateQuartzExpression(cr.String())
+}
+
+// ValidateQuartzExpression validates a Quartz scheduler expression such as "0 0/15 9-17 ? * MON-FRI 2026". It checks that the expression has six or seven fields
+// which all follow the cron grammar within the bounds for their position, and that exactly one of the day fields is the "?" placeholder.
+// The day fields may also hold one of the special terms L, W, LW or #; see parseSpecial.
+func ValidateQuartzExpression(expr string) (bool, error) {
+	return Quartz.Validate(expr)
+}
+
+// DecodeQuartz converts a CronRead holding a Quartz expression into its CronExpressionDecoded, with Second always set and Year set when written.
+// It returns nil when the expression is invalid; use Quartz.Parse to learn why.
+func (cr *CronRead) DecodeQuartz() *CronExpressionDecoded {
+	dec, err := Quartz.Decode(cr.String())
+	if err != nil {
+		return nil
+	}
+	return dec
+}
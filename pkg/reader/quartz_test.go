@@ -0,0 +1,131 @@
+package reader
+
+import (
+	"fmt"
+)
+
+var QuartzTestInputs = state{
+	[]string{
+		"0 0/15 9-17 ? * MON-FRI 2026", // seven fields
+		"0 0 12 * * ?",                 // six fields
+		"30 15 10 ? * 6",               // numbered day of week
+		"0 0 12 * * ? 2026-2030/2",     // stepped years
+		"0 0 12 * * *",                 // neither day field is ?
+		"0 0 12 ? * ?",                 // both day fields are ?
+		"0 0 12 ? ? *",                 // ? in month
+		"0 0 12 1,? * *",               // ? in a list
+		"60 0 12 * * ?",                // seconds over bounds
+		"0 0 24 * * ?",                 // hours over bounds
+		"0 0 12 ? * 0",                 // days of the week count from 1
+		"0 0 12 * * ? 1969",            // year under bounds
+		"0 0 12 L * ?",                 // last day of month
+		"0 0 12 L-3 * ?",               // days before the last day of month
+		"0 0 12 15W * ?",               // nearest weekday
+		"0 0 12 LW * ?",                // last weekday of month
+		"0 0 12 ? * 6L",                // last Friday
+		"0 0 12 ? * FRIL",              // last Friday by name
+		"0 0 12 ? * 6#3",               // third Friday
+		"0 0 12 ? * L",                 // Saturday
+		"0 0 12 lw * ?",                // lower case special
+		"0 0 12 L,15 * ?",              // special in a list
+		"0 0 12 32W * ?",               // nearest weekday over bounds
+		"0 0 12 L-31 * ?",              // offset over bounds
+		"0 0 12 ? * 6#6",               // occurrence over bounds
+		"0 0 12 ? * 6W",                // nearest weekday in day of week
+		"0 0 12 6#3 * ?",               // occurrence in day of month
+		"0 L 12 * * ?",                 // special outside the day fields
+		"0 0 12 * *",                   // missing field
+		"0 0 12 * * ? 2026 1",          // extra field
+	},
+	[]bool{
+		true,  // seven fields
+		true,  // six fields
+		true,  // numbered day of week
+		true,  // stepped years
+		false, // neither day field is ?
+		false, // both day fields are ?
+		false, // ? in month
+		false, // ? in a list
+		false, // seconds over bounds
+		false, // hours over bounds
+		false, // days of the week count from 1
+		false, // year under bounds
+		true,  // last day of month
+		true,  // days before the last day of month
+		true,  // nearest weekday
+		true,  // last weekday of month
+		true,  // last Friday
+		true,  // last Friday by name
+		true,  // third Friday
+		true,  // Saturday
+		true,  // lower case special
+		false, // special in a list
+		false, // nearest weekday over bounds
+		false, // offset over bounds
+		false, // occurrence over bounds
+		false, // nearest weekday in day of week
+		false, // occurrence in day of month
+		false, // special outside the day fields
+		false, // missing field
+		false, // extra field
+	},
+}
+
+// TestValidateQuartz tests that Quartz expressions are validated against their own layout
+func (c *CronTab) TestValidateQuartz() {
+	v := QuartzTestInputs
+	for i := 0; i < len(v.input); i++ {
+		actual, _ := ValidateQuartzExpression(v.input[i])
+		c.Assert().Equal(v.expected[i], actual, fmt.Sprintf("expected %v, got %v. input: %v", v.expected[i], actual, v.input[i]))
+	}
+}
+
+// TestDecodeQuartz tests that the seconds and year fields of Quartz expressions are decoded, and that the year is left out when not written
+func (c *CronTab) TestDecodeQuartz() {
+	read := CronRead("0 0/15 9-17 ? * MON-FRI 2026")
+	dec := read.DecodeQuartz()
+	c.Require().NotNil(dec)
+	c.Require().NotNil(dec.Second)
+	c.Assert().Equal([]int{0}, dec.Second.Values())
+	c.Assert().Equal([]int{0, 15, 30, 45}, dec.Minute.Values())
+	c.Assert().Equal(DelimAny, dec.DayOfMonth.DelimKind)
+	c.Assert().Equal([]int{2, 3, 4, 5, 6}, dec.DayOfWeek.Values())
+	c.Require().NotNil(dec.Year)
+	c.Assert().Equal([]int{2026}, dec.Year.Values())
+
+	keys, _ := dec.FlattenToMap()
+	c.Assert().Equal([]string{Second, Minute, Hour, DayOfTheMonth, Month, DayOfTheWeek, Year}, keys)
+
+	read = CronRead("0 0 12 * * ?")
+	dec = read.DecodeQuartz()
+	c.Require().NotNil(dec)
+	c.Assert().Nil(dec.Year)
+
+	read = CronRead("0 0 12 * * *")
+	c.Assert().Nil(read.DecodeQuartz())
+}
+
+// TestDecodeQuartzSpecials tests that the Quartz special terms decode into their own delimiter kinds
+func (c *CronTab) TestDecodeQuartzSpecials() {
+	cases := map[string]Span{
+		"0 0 12 L * ?":    {Kind: DelimLast},
+		"0 0 12 L-3 * ?":  {Kind: DelimLast, Offset: 3},
+		"0 0 12 15W * ?":  {Low: 15, High: 15, Kind: DelimWeekday},
+		"0 0 12 LW * ?":   {Kind: DelimLastWeekday},
+		"0 0 12 ? * 6L":   {Low: 6, High: 6, Kind: DelimLast},
+		"0 0 12 ? * FRIL": {Low: 6, High: 6, Kind: DelimLast},
+		"0 0 12 ? * 6#3":  {Low: 6, High: 6, Nth: 3, Kind: DelimNth},
+		"0 0 12 ? * L":    {Low: 7, High: 7, Kind: DelimNone},
+	}
+	for expr, expected := range cases {
+		read := CronRead(expr)
+		dec := read.DecodeQuartz()
+		c.Require().NotNil(dec, expr)
+		day := dec.DayOfMonth
+		if day.DelimKind == DelimAny {
+			day = dec.DayOfWeek
+		}
+		c.Assert().Equal([]Span{expected}, day.Spans, expr)
+		c.Assert().Equal(expected.Kind, day.DelimKind, expr)
+	}
+}
@@ -1,9 +1,7 @@
 package reader
 
 import (
-	"bytes"
 	"fmt"
-	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -15,6 +13,16 @@ const (
 	DelimComma
 	DelimRange
 	DelimEvery
+	// DelimAny is the "?" placeholder of Quartz, which leaves the field unrestricted for the other day field to decide
+	DelimAny
+	// DelimLast is the Quartz "L": the last day of the month, n days before it, or the last given day of the week in the month
+	DelimLast
+	// DelimWeekday is the Quartz "nW": the weekday nearest to the given day of the month
+	DelimWeekday
+	// DelimLastWeekday is the Quartz "LW": the last weekday of the month
+	DelimLastWeekday
+	// DelimNth is the Quartz "n#m": the m-th given day of the week in the month
+	DelimNth
 )
 
 const (
@@ -25,34 +33,30 @@ const (
 	DayOfTheWeek  = "dayOfTheWeek"
 )
 
+// fieldOrder lists the fields of a cron expression in the order they are written
+var fieldOrder = []string{Minute, Hour, DayOfTheMonth, Month, DayOfTheWeek}
+
 var (
-	Bounds = []struct {
-		low  int
-		high int
-	}{
-		{
-			0,
-			60,
-		},
-		{
-			0,
-			60,
-		},
-		{
-			1,
-			31,
-		},
-		{
-			1,
-			12,
-		},
-		{
-			1,
-			7,
-		},
+	// MonthNames maps the names accepted in the month field to the month they stand for. Names are matched case-insensitively.
+	MonthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	// DayNames maps the names accepted in the day of the week field to the day they stand for, counting from Sunday as 0. Names are matched case-insensitively.
+	DayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
 	}
 )
 
+// Bounds holds the FieldSpec of each field of a Vixie cron expression, keyed by the field it describes
+var Bounds = map[string]FieldSpec{
+	Minute:        {Name: Minute, Low: 0, High: 59},
+	Hour:          {Name: Hour, Low: 0, High: 23},
+	DayOfTheMonth: {Name: DayOfTheMonth, Low: 1, High: 31},
+	Month:         {Name: Month, Low: 1, High: 12, Aliases: MonthNames},
+	DayOfTheWeek:  {Name: DayOfTheWeek, Low: 0, High: 7, Aliases: DayNames, Equal: map[int]int{7: 0}},
+}
+
 var (
 	SampleCronFile = `
 0 9 * * 6
@@ -68,15 +72,27 @@ type CronExpression struct {
 	DayOfMonth string
 	Month      string
 	DayOfWeek  string
+	// Macro is the nickname the expression was written as, such as @daily; it is empty for expressions written as fields
+	Macro string
 }
 
 // CronExpressionDecoded holds a deep understanding of the cron expression passed in a deeper struct
 type CronExpressionDecoded struct {
+	// Second is only set for formats with a seconds field, such as Quartz
+	Second     *Catcher
 	Minute     Catcher
 	Hour       Catcher
 	DayOfMonth Catcher
 	Month      Catcher
 	DayOfWeek  Catcher
+	// Year is only set for formats with a year field, such as Quartz, and when the year is written
+	Year *Catcher
+	// Macro is the nickname the expression was written as, such as @daily; it is empty for expressions written as fields
+	Macro string
+	// Trigger is TriggerReboot for @reboot, whose time fields are left empty, and TriggerTime otherwise
+	Trigger int
+	// source is the expression as read, and read what String wrote of it then, telling whether the expression was changed since
+	source, read string
 }
 
 // FlattenToMap helps with easily accessing the values of CronExpressionDecoded in meaning.Explain by keys; helps minimize time complexity on retrieval
@@ -88,249 +104,135 @@ func (c *CronExpressionDecoded) FlattenToMap() ([]string, map[string]*Catcher) {
 	mapper[Month] = &c.Month
 	mapper[DayOfTheWeek] = &c.DayOfWeek
 	// Ordered keys so that I can range over the map in an orderly manner
-	orderedKeys := []string{Minute, Hour, DayOfTheMonth, Month, DayOfTheWeek}
+	orderedKeys := append([]string{}, fieldOrder...)
+	if c.Second != nil {
+		mapper[Second] = c.Second
+		orderedKeys = append([]string{Second}, orderedKeys...)
+	}
+	if c.Year != nil {
+		mapper[Year] = c.Year
+		orderedKeys = append(orderedKeys, Year)
+	}
 	return orderedKeys, mapper
 }
 
+// set stores the Catcher decoded for the named field
+func (c *CronExpressionDecoded) set(field string, catch Catcher) {
+	switch field {
+	case Second:
+		c.Second = &catch
+	case Minute:
+		c.Minute = catch
+	case Hour:
+		c.Hour = catch
+	case DayOfTheMonth:
+		c.DayOfMonth = catch
+	case Month:
+		c.Month = catch
+	case DayOfTheWeek:
+		c.DayOfWeek = catch
+	case Year:
+		c.Year = &catch
+	}
+}
+
 // Catcher holds a unit of deep cron expression knowledge. It represents the type of token passed in at a time, and the valid bounds for any token at that position.
 type Catcher struct {
+	// Low, High and DelimKind sum up Spans as summarize lays them out. Fields may also be built or changed through them, as Terms describes.
 	Low       int
 	High      []int
 	DelimKind int
-}
-
-// OpenCrontableFile opens the crontab file passed in as argument, casting it into wrapper type CronRead before returning. It errors with os.File errors, and when the file is structurally invalid
+	// Raw is the token as written in the cron expression, keeping any month or day names as spelled
+	Raw string
+	// Spans holds every comma-separated term of the token, in the order written
+	Spans []Span
+	// equal is the FieldSpec.Equal of the field the token was read from, folding values that stand for another when expanded
+	equal map[int]int
+	// stepFrom is the FieldSpec.StepFrom of the field the token was read from, telling String it may write a step from a single value as such
+	stepFrom bool
+	// parsed holds a copy of Spans as read from Raw, telling whether the terms were changed since
+	parsed []Span
+}
+
+// OpenCrontableFile opens the crontab file passed in as argument, casting its first expression into wrapper type CronRead before returning. Blank lines and "#" comments
+// before it are skipped. It errors with os.File errors, with ErrNoExpression when the file holds no expression, and with ErrFieldCount when the expression
+// is neither a nickname nor five fields.
 func OpenCrontableFile(loc string) (*CronRead, error) {
-	file, err := os.ReadFile(loc)
+	file, err := os.Open(loc)
 	if err != nil {
-		log.Printf("crontab is invalid. \nsample cronfile: %v\n", SampleCronFile)
 		return nil, err
 	}
+	defer file.Close()
 
-	cronTabExpr, _, found := bytes.Cut(file, []byte("\n"))
-	if !found {
-		bytesLength := len(bytes.Split(cronTabExpr, []byte(" ")))
-		if bytesLength != 5 {
-			log.Printf("crontab is invalid. the number of crontable arguments is %d \nsample cronfile: %v\n", strings.Count(string(cronTabExpr), " "), SampleCronFile)
-			os.Exit(1)
-			return nil, fmt.Errorf("crontab is invalid. the number of crontable arguments is %d \nsample cronfile: %s\n", bytesLength, SampleCronFile)
-		}
+	cronTabExpr, err := firstExpression(file)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(tokenize(cronTabExpr)); !isMacro(cronTabExpr) && n != len(fieldOrder) {
+		return nil, errorf(ErrFieldCount, "crontab is invalid. the number of crontable arguments is %d, expected %d", n, len(fieldOrder))
 	}
-	read := CronRead(string(cronTabExpr))
+	read := CronRead(cronTabExpr)
 	return &read, nil
 }
 
 // Validate validates a CronRead value. It checks that all the tokens are valid, and/or are within the bounds for their position
 func (cr *CronRead) Validate() (bool, error) {
-	str := cr.String()
-	valErr := 0
-	pieces := strings.Split(str, " ")
-	for i := 0; i < len(pieces); i++ {
-		if !validate(pieces[i]) {
-			valErr++
-		}
-	}
+	return ValidateExpression(cr.String())
+}
 
-	if valErr > 0 {
-		return false, fmt.Errorf("encountered %d validation errors", valErr)
-	}
-	return true, nil
+// ValidateExpression validates a cron expression string. It checks that the expression is a known nickname, or has five fields which all follow the cron grammar
+// within the bounds for their position. The error returned joins the failure of every invalid field.
+func ValidateExpression(expr string) (bool, error) {
+	return Vixie.Validate(expr)
 }
 
 // Decode converts a CronRead into its CronExpressionDecoded, breaking its tokens into their separate units and preserving meaning.
+// Nicknames decode into the expression they stand for, with Macro recording the nickname; @reboot decodes into a TriggerReboot with no time fields.
+// It returns nil when the expression is invalid; use Parse to learn why.
 func (cr *CronRead) Decode() *CronExpressionDecoded {
-	str := cr.String()
-	var catchAll []Catcher
-	pieces := strings.Split(str, " ")
-	for i := 0; i < len(pieces); i++ {
-		var catch Catcher
-		var err error
-		catch.Low, catch.High, _, catch.DelimKind, err = validateWithFields(pieces[i], Bounds[i].low, Bounds[i].high)
-		if err != nil {
-			log.Println(fmt.Errorf("w"), err)
-		}
-		catchAll = append(catchAll, catch)
-	}
-	var catchAllDecoded CronExpressionDecoded
-	catchAllDecoded.Minute = catchAll[0]
-	catchAllDecoded.Hour = catchAll[1]
-	catchAllDecoded.DayOfMonth = catchAll[2]
-	catchAllDecoded.Month = catchAll[3]
-	catchAllDecoded.DayOfWeek = catchAll[4]
-	return &catchAllDecoded
-}
-
-// validate does a quick and shallow validation the string cron string passed into cron table. It returns a bool which signifies if the string is valid or not.
-func validate(s string) bool {
-	if s == "*" || canBeNumber(s) || containFavoredDelimiter(s) || startSlash(s) {
-		return true
-	}
-	return false
-}
-
-// validateWithFields validates that the string argument passed in is a valid cron argument.
-// It returns the low, high, boolean (true if string is valid); a false boolean, and error if string isn't
-func validateWithFields(s string, low, high int) (int, []int, bool, int, error) {
-	if s == "*" {
-		return low, []int{high}, true, DelimWildcard, nil
-	}
-	if canBeNumber(s) {
-		i, err := strconv.Atoi(s)
-		if err != nil {
-			return 0, []int{0}, false, DelimNone, nil
-		}
-		if i < low || i > high {
-			return 0, []int{0}, false, DelimNone, fmt.Errorf("number %d not within acceptable bounds", i)
-		}
-		return i, []int{i}, true, DelimNone, nil
-	}
-	containDelim, delim, pieces := containFavoredDelimiterWhich(s)
-	if containDelim {
-		if len(pieces) < 2 {
-			return 0, []int{-1}, false, delim, nil
-		}
-		var retInt []int
-		for i := 0; i < len(pieces); i++ {
-			if canBeNumber(pieces[i]) {
-				i, err := strconv.Atoi(pieces[i])
-				if err != nil {
-					return 0, []int{0}, false, delim, nil
-				}
-				if i < low || i > high {
-					return 0, []int{0}, false, delim, fmt.Errorf("number %d not within acceptable bounds", i)
-				}
-				retInt = append(retInt, i)
-			}
-		}
-		return retInt[0], retInt[1:], true, delim, nil
-	}
-
-	isStartSlash, str := startSlashWhich(s)
-	if isStartSlash {
-		if canBeNumber(str) {
-			i, err := strconv.Atoi(s)
-			if err != nil {
-				return 0, []int{0}, false, delim, nil
-			}
-			if i < low || i > high {
-				return 0, []int{0}, false, delim, fmt.Errorf("number %d not within acceptable bounds", i)
-			}
-			return i, []int{i}, true, delim, nil
-		}
-		return 0, []int{0}, false, delim, fmt.Errorf("invald string cannot be number in %v", str)
-	}
-	return 0, []int{0}, false, delim, fmt.Errorf("invald string passed in %v", s)
-}
-
-func startSlash(s string) bool {
-	_, aft, found := strings.Cut("/", s)
-	if !found {
-
-		if !canBeNumber(aft) {
-			return false
-		}
-	}
-	log.Println("does not contain slash")
-	return false
-}
-
-func startSlashWhich(s string) (bool, string) {
-	_, aft, found := strings.Cut("/", s)
-	if !found {
-
-		if !canBeNumber(aft) {
-			return false, aft
-		}
+	dec, err := Vixie.Decode(cr.String())
+	if err != nil {
+		return nil
 	}
-	log.Println("does not contain slash")
-	return false, ""
+	return dec
 }
 
+// canBeNumber reports whether s is a number written in ASCII digits alone, as cron reads them: signs such as "+5" are not accepted
 func canBeNumber(s string) bool {
-	_, err := strconv.Atoi(s)
-	if err != nil {
+	if s == "" {
 		return false
 	}
-	return true
-}
-
-func containFavoredDelimiter(s string) bool {
-	if strings.Contains(s, "-") {
-		log.Println("contains delimiter -")
-		byDash := strings.Split(s, "-")
-		log.Printf("string split into %v", byDash)
-		if len(byDash) != 2 {
-			log.Printf("len of string %v is not 2", byDash)
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
 			return false
-		} else if len(byDash) == 2 {
-			for i := 0; i < len(byDash); i++ {
-				if !canBeNumber(byDash[i]) {
-					return false
-				}
-			}
-			log.Printf("len of string %v is 2", byDash)
-			return true
-		}
-	}
-
-	if strings.Contains(s, ",") {
-		log.Println("contains delimiter ,")
-		byComma := strings.Split(s, ",")
-		log.Printf("string split into %v", byComma)
-		if len(byComma) != 2 {
-			log.Printf("len of string %v is not 2", byComma)
-			return false
-		} else if len(byComma) == 2 {
-			log.Printf("len of string %v is 2", byComma)
-			return true
 		}
 	}
-
-	log.Println("does not contain favored delimiter")
-	return false
+	_, err := strconv.Atoi(s)
+	return err == nil
 }
 
-func containFavoredDelimiterWhich(s string) (bool, int, []string) {
-	if strings.Contains(s, "-") {
-		log.Println("contains delimiter -")
-		byDash := strings.Split(s, "-")
-		log.Printf("string split into %v", byDash)
-		if len(byDash) != 2 {
-			log.Printf("len of string %v is not 2", byDash)
-			return false, DelimRange, byDash
-		} else if len(byDash) == 2 {
-			for i := 0; i < len(byDash); i++ {
-				if !canBeNumber(byDash[i]) {
-					return false, DelimRange, byDash
-				}
-			}
-			log.Printf("len of string %v is 2", byDash)
-			return true, DelimRange, byDash
+// MarshalIntoCronExpression splits a CronRead into the five fields of a CronExpression. Nicknames are expanded into the fields they stand for,
+// leaving every field empty for @reboot.
+func (cr *CronRead) MarshalIntoCronExpression() (*CronExpression, error) {
+	str := cr.String()
+	if isMacro(str) {
+		expanded, err := expandMacro(str, Macros)
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	if strings.Contains(s, ",") {
-		log.Println("contains delimiter ,")
-		byComma := strings.Split(s, ",")
-		log.Printf("string split into %v", byComma)
-		if len(byComma) < 2 {
-			log.Printf("len of string %v is not 2", byComma)
-			return false, DelimComma, byComma
-		} else if len(byComma) >= 2 {
-			log.Printf("len of string %v is 2", byComma)
-			return true, DelimComma, byComma
+		read := CronRead(expanded)
+		cExpr := &CronExpression{}
+		if expanded != "" {
+			if cExpr, err = read.MarshalIntoCronExpression(); err != nil {
+				return nil, err
+			}
 		}
+		cExpr.Macro = strings.TrimSpace(str)
+		return cExpr, nil
 	}
-
-	log.Println("does not contain favored delimiter")
-	return false, 0, []string{}
-}
-
-func (cr *CronRead) MarshalIntoCronExpression() (*CronExpression, error) {
-	str := cr.String()
 	pieces := strings.Split(str, " ")
 	if len(pieces) < 5 {
-		return nil, fmt.Errorf("formatted cron expression has less than 5 arguments: %v", cr)
+		return nil, errorf(ErrFieldCount, "formatted cron expression has less than 5 arguments: %v", cr)
 	}
 	return &CronExpression{
 		Minute:     pieces[0],
@@ -24,124 +24,180 @@ type CronTab struct {
 var CrontableTestInputs = map[string]state{
 	"minute": state{
 		[]string{
-			"0 * * * 6",   // missing
-			"0 9 * * SAT", // within bounds
-			"0 9 * * SUN", // over bounds
-			"3 9 * * 7",   // missing
-			"21 9 * * 7",  // within bounds
-			"89 9 * * 7",  // over bounds
-			"0 9 * * 7",   // missing
-			"0 9 * * 7",   // within bounds
-			"0 9 * * 7",   // over bounds
+			"0 9 * * 6",  // lower bound
+			"59 9 * * 6", // upper bound
+			"60 9 * * 6", // over bounds
+			"- 9 * * 6",  // missing
+			"x 9 * * 6",  // not a number
+			"+5 9 * * 6", // signed
 		},
 		[]bool{
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
+			true,  // lower bound
+			true,  // upper bound
 			false, // over bounds
 			false, // missing
-			true,  // within bounds
-			false, // over bounds
+			false, // not a number
+			false, // signed
 		},
 	},
 	"hour": state{
 		[]string{
-			"0 * * * 6",   // missing
-			"0 9 * * SAT", // within bounds
-			"0 9 * * SUN", // over bounds
-			"3 9 * * 7",   // missing
-			"21 9 * * 7",  // within bounds
-			"89 9 * * 7",  // over bounds
-			"0 9 * * 7",   // missing
-			"0 9 * * 7",   // within bounds
-			"0 9 * * 7",   // over bounds
+			"0 0 * * 6",  // lower bound
+			"0 23 * * 6", // upper bound
+			"0 24 * * 6", // over bounds
+			"0 -1 * * 6", // negative
 		},
 		[]bool{
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
+			true,  // lower bound
+			true,  // upper bound
 			false, // over bounds
+			false, // negative
 		},
 	},
 	"day_of_month": state{
 		[]string{
-			"0 * * * 6",   // missing
-			"0 9 * * SAT", // within bounds
-			"0 9 * * SUN", // over bounds
-			"3 9 * * 7",   // missing
-			"21 9 * * 7",  // within bounds
-			"89 9 * * 7",  // over bounds
-			"0 9 * * 7",   // missing
-			"0 9 * * 7",   // within bounds
-			"0 9 * * 7",   // over bounds
+			"0 9 1 * 6",  // lower bound
+			"0 9 31 * 6", // upper bound
+			"0 9 0 * 6",  // under bounds
+			"0 9 32 * 6", // over bounds
 		},
 		[]bool{
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
+			true,  // lower bound
+			true,  // upper bound
+			false, // under bounds
 			false, // over bounds
 		},
 	},
 	"month": state{
 		[]string{
-			"0 * * * 6",   // missing
-			"0 9 * * SAT", // within bounds
-			"0 9 * * SUN", // over bounds
-			"3 9 * * 7",   // missing
-			"21 9 * * 7",  // within bounds
-			"89 9 * * 7",  // over bounds
-			"0 9 * * 7",   // missing
-			"0 9 * * 7",   // within bounds
-			"0 9 * * 7",   // over bounds
+			"0 9 * 1 6",  // lower bound
+			"0 9 * 12 6", // upper bound
+			"0 9 * 0 6",  // under bounds
+			"0 9 * 13 6", // over bounds
 		},
 		[]bool{
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
+			true,  // lower bound
+			true,  // upper bound
+			false, // under bounds
 			false, // over bounds
 		},
 	},
 	"day_of_week": state{
 		[]string{
-			"0 * * * 6",   // missing
-			"0 9 * * SAT", // within bounds
-			"0 9 * * SUN", // over bounds
-			"3 9 * * 7",   // missing
-			"21 9 * * 7",  // within bounds
-			"89 9 * * 7",  // over bounds
-			"0 9 * * 7",   // missing
-			"0 9 * * 7",   // within bounds
-			"0 9 * * 7",   // over bounds
+			"0 9 * * 0",   // sunday as 0
+			"0 9 * * 7",   // sunday as 7
+			"0 9 * * 5-7", // range into sunday
+			"0 9 * * 8",   // over bounds
 		},
 		[]bool{
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
-			false, // over bounds
-			false, // missing
-			true,  // within bounds
+			true,  // sunday as 0
+			true,  // sunday as 7
+			true,  // range into sunday
 			false, // over bounds
 		},
 	},
+	"names": state{
+		[]string{
+			"0 9 * * SAT",         // day name
+			"0 9 * * sun",         // lower case day name
+			"0 9 * * MON-FRI",     // day name range
+			"0 9 * JAN,APR,JUL *", // month name list
+			"0 9 * Jan-Jun/2 *",   // stepped month name range
+			"0 9 * * MON,3-FRI",   // names mixed with numbers
+			"0 9 * * FOO",         // unknown name
+			"0 9 MON * *",         // name outside its field
+			"0 9 * JAN-MON *",     // day name in month field
+			"0 9 * * */MON",       // name as step
+		},
+		[]bool{
+			true,  // day name
+			true,  // lower case day name
+			true,  // day name range
+			true,  // month name list
+			true,  // stepped month name range
+			true,  // names mixed with numbers
+			false, // unknown name
+			false, // name outside its field
+			false, // day name in month field
+			false, // name as step
+		},
+	},
+	"macros": state{
+		[]string{
+			"@daily",         // nickname
+			"@reboot",        // startup trigger
+			" @hourly ",      // surrounding whitespace
+			"@fortnightly",   // unknown nickname
+			"@DAILY",         // nicknames are case sensitive
+			"@daily 0 9 * *", // nickname with fields
+		},
+		[]bool{
+			true,  // nickname
+			true,  // startup trigger
+			true,  // surrounding whitespace
+			false, // unknown nickname
+			false, // nicknames are case sensitive
+			false, // nickname with fields
+		},
+	},
+	"field_count": state{
+		[]string{
+			"0 9 * *",       // missing field
+			"0 9 * * 6 1",   // extra field
+			"0  9 *   *\t6", // irregular whitespace
+			"",              // empty
+		},
+		[]bool{
+			false, // missing field
+			false, // extra field
+			true,  // irregular whitespace
+			false, // empty
+		},
+	},
+	"steps": state{
+		[]string{
+			"*/15 * * * *",   // every 15 minutes
+			"0 9-17/2 * * *", // stepped range
+			"5/10 * * * *",   // stepped start value
+			"*/0 * * * *",    // zero step
+			"*/x * * * *",    // step not a number
+			"*/+5 * * * *",   // signed step
+			"*/15/2 * * * *", // double step
+			"0 */99 * * *",   // step wider than field
+			"1-5/ * * * *",   // missing step
+		},
+		[]bool{
+			true,  // every 15 minutes
+			true,  // stepped range
+			false, // stepped start value
+			false, // zero step
+			false, // step not a number
+			false, // signed step
+			false, // double step
+			false, // step wider than field
+			false, // missing step
+		},
+	},
+	"lists": state{
+		[]string{
+			"1-5,10,20-30/2 * * * *", // ranges, values and steps
+			"0,15,30,45 * * * *",     // values
+			"0 9 * * 1-5,7",          // range and value
+			"1,,2 * * * *",           // empty element
+			"1, * * * *",             // trailing comma
+			"5-1 * * * *",            // reversed range
+			"1-2-3 * * * *",          // double range
+		},
+		[]bool{
+			true,  // ranges, values and steps
+			true,  // values
+			true,  // range and value
+			false, // empty element
+			false, // trailing comma
+			false, // reversed range
+			false, // double range
+		},
+	},
 }
 
 func (c *CronTab) SetupTest() {
@@ -157,7 +213,7 @@ func (c *CronTab) SetupTest() {
 func (c *CronTab) TestValidation() {
 	log := c.log
 	for k, v := range *c.testState {
-		log.Println("Validating section %v", k)
+		log.Printf("Validating section %v", k)
 		for i := 0; i < len(v.input); i++ {
 			actual, _ := ValidateExpression(v.input[i])
 			log.Println("result from validation:", actual)
@@ -166,6 +222,69 @@ func (c *CronTab) TestValidation() {
 	}
 }
 
+// TestDecodeSteps tests that stepped and listed tokens decode into their spans and expanded values
+func (c *CronTab) TestDecodeSteps() {
+	read := CronRead("1-5,10,20-30/2 */6 5-31/10 * 1-7/3")
+	dec := read.Decode()
+	c.Require().NotNil(dec)
+
+	c.Assert().Equal(DelimComma, dec.Minute.DelimKind)
+	c.Assert().Equal([]Span{
+		{Low: 1, High: 5, Kind: DelimRange},
+		{Low: 10, High: 10, Kind: DelimNone},
+		{Low: 20, High: 30, Step: 2, Kind: DelimRange},
+	}, dec.Minute.Spans)
+	c.Assert().Equal([]int{1, 2, 3, 4, 5, 10, 20, 22, 24, 26, 28, 30}, dec.Minute.Values())
+
+	c.Assert().Equal(DelimEvery, dec.Hour.DelimKind)
+	c.Assert().Equal("*/6", dec.Hour.Raw)
+	c.Assert().Equal(6, dec.Hour.Low)
+
+	c.Assert().Equal([]int{5, 15, 25}, dec.DayOfMonth.Values())
+	c.Assert().Equal(DelimWildcard, dec.Month.DelimKind)
+	c.Assert().Equal([]int{0, 1, 4}, dec.DayOfWeek.Values())
+}
+
+// TestDecodeNames tests that month and day names decode into their numbers while keeping their spelling
+func (c *CronTab) TestDecodeNames() {
+	read := CronRead("0 9 * jan,Apr,JUL MON-FRI")
+	dec := read.Decode()
+	c.Require().NotNil(dec)
+
+	c.Assert().Equal([]int{1, 4, 7}, dec.Month.Values())
+	c.Assert().Equal("jan,Apr,JUL", dec.Month.Raw)
+	c.Assert().Equal(DelimRange, dec.DayOfWeek.DelimKind)
+	c.Assert().Equal(1, dec.DayOfWeek.Low)
+	c.Assert().Equal([]int{5}, dec.DayOfWeek.High)
+	c.Assert().Equal("MON-FRI", dec.DayOfWeek.Raw)
+}
+
+// TestDecodeMacros tests that nicknames decode into the fields they stand for, and that @reboot decodes into its own trigger
+func (c *CronTab) TestDecodeMacros() {
+	weekly := CronRead("@weekly")
+	dec := weekly.Decode()
+	c.Require().NotNil(dec)
+	c.Assert().Equal("@weekly", dec.Macro)
+	c.Assert().Equal(TriggerTime, dec.Trigger)
+	c.Assert().Equal([]int{0}, dec.Minute.Values())
+	c.Assert().Equal([]int{0}, dec.Hour.Values())
+	c.Assert().Equal(DelimWildcard, dec.DayOfMonth.DelimKind)
+	c.Assert().Equal([]int{0}, dec.DayOfWeek.Values())
+
+	reboot := CronRead("@reboot")
+	dec = reboot.Decode()
+	c.Require().NotNil(dec)
+	c.Assert().Equal(TriggerReboot, dec.Trigger)
+	c.Assert().Empty(dec.Minute.Spans)
+
+	expr, err := reboot.MarshalIntoCronExpression()
+	c.Require().NoError(err)
+	c.Assert().Equal(&CronExpression{Macro: "@reboot"}, expr)
+
+	unknown := CronRead("@fortnightly")
+	c.Assert().Nil(unknown.Decode())
+}
+
 func (c *CronTab) TearDownSuite() {
 	log := c.log
 	log.Println("Commencing test cleanup")
@@ -0,0 +1,82 @@
+package reader
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// Schedule is a cron expression parsed for use as a library: the expression as written, the dialect it was read in, and the fields it decodes into.
+// The fields of the embedded CronExpressionDecoded are reachable directly, as in s.Minute.
+type Schedule struct {
+	// Expr is the cron expression as written, with surrounding whitespace trimmed
+	Expr string
+	// Dialect is the cron implementation whose rules the expression was read by
+	Dialect *Dialect
+	// Location is the time zone the schedule fires in, as set by a CRON_TZ line of a crontab. When nil, firing times are worked out in the location
+	// of the time they are searched from.
+	Location *time.Location
+	*CronExpressionDecoded
+}
+
+// Parse parses a Vixie cron expression such as "*/15 9-17 * * MON-FRI" or "@daily" into a Schedule. See Dialect.Parse.
+func Parse(expr string) (*Schedule, error) {
+	return Vixie.Parse(expr)
+}
+
+// ParseInLocation parses a Vixie cron expression into a Schedule firing in the time zone loc. See Dialect.Parse.
+func ParseInLocation(expr string, loc *time.Location) (*Schedule, error) {
+	return Vixie.ParseInLocation(expr, loc)
+}
+
+// ParseReader parses the first Vixie cron expression read from r. See Dialect.ParseReader.
+func ParseReader(r io.Reader) (*Schedule, error) {
+	return Vixie.ParseReader(r)
+}
+
+// Parse parses a cron expression string into a Schedule following the rules of the dialect. It never logs nor exits: the error returned joins a Diagnostic
+// for every problem found in the expression, each wrapping the sentinel error classifying it, so that errors.Is(err, ErrOutOfRange) and
+// errors.As(err, &diagnostic) both work on it.
+func (d *Dialect) Parse(expr string) (*Schedule, error) {
+	dec, err := d.Decode(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{Expr: strings.TrimSpace(expr), Dialect: d, CronExpressionDecoded: dec}, nil
+}
+
+// ParseInLocation parses a cron expression string into a Schedule following the rules of the dialect, firing in the time zone loc. See Dialect.Parse.
+func (d *Dialect) ParseInLocation(expr string, loc *time.Location) (*Schedule, error) {
+	sched, err := d.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	sched.Location = loc
+	return sched, nil
+}
+
+// ParseReader parses the first cron expression read from r following the rules of the dialect, skipping blank lines and "#" comments before it.
+// It errors with ErrNoExpression when r holds no expression.
+func (d *Dialect) ParseReader(r io.Reader) (*Schedule, error) {
+	expr, err := firstExpression(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.Parse(expr)
+}
+
+// firstExpression returns the first line read from r that is neither blank nor a "#" comment
+func firstExpression(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errorf(ErrNoExpression, "no cron expression found")
+}
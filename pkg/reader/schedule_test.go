@@ -0,0 +1,67 @@
+package reader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SentinelTestInputs maps invalid expressions to the sentinel error expected to classify their failure
+var SentinelTestInputs = map[string]error{
+	"0 9 * *":        ErrFieldCount,
+	"0 24 * * *":     ErrOutOfRange,
+	"*/0 * * * *":    ErrBadStep,
+	"5/10 * * * *":   ErrBadStep,
+	"x 9 * * *":      ErrBadValue,
+	"0 9 * 5-1 *":    ErrBadRange,
+	"0 9 1,,2 * *":   ErrEmpty,
+	"@fortnightly":   ErrUnknownMacro,
+	"0 9 ? * MON":    ErrPlaceholder,
+	"0 9 1 * * 2026": ErrFieldCount,
+}
+
+// TestParse tests that Parse returns a Schedule for valid expressions, and errors usable with errors.Is and errors.As for invalid ones
+func (c *CronTab) TestParse() {
+	sched, err := Parse("  */15 9-17 * * MON-FRI ")
+	c.Require().NoError(err)
+	c.Assert().Equal("*/15 9-17 * * MON-FRI", sched.Expr)
+	c.Assert().Equal(Vixie, sched.Dialect)
+	c.Assert().Equal([]int{1, 2, 3, 4, 5}, sched.DayOfWeek.Values())
+
+	for expr, kind := range SentinelTestInputs {
+		sched, err := Parse(expr)
+		c.Assert().Nil(sched, expr)
+		c.Assert().ErrorIs(err, kind, expr)
+
+		var diag Diagnostic
+		c.Require().True(errors.As(err, &diag), expr)
+		c.Assert().Equal(kind, diag.Kind, expr)
+	}
+
+	_, err = Quartz.Parse("0 9 * * MON")
+	c.Assert().ErrorIs(err, ErrFieldCount)
+}
+
+// TestParseReader tests that ParseReader skips comments and blank lines to the first expression
+func (c *CronTab) TestParseReader() {
+	sched, err := ParseReader(strings.NewReader("# backups\n\n@daily\n0 9 * * *\n"))
+	c.Require().NoError(err)
+	c.Assert().Equal("@daily", sched.Macro)
+
+	_, err = ParseReader(strings.NewReader("# nothing here\n"))
+	c.Assert().ErrorIs(err, ErrNoExpression)
+}
+
+// TestOpenCrontableFile tests that a structurally invalid file is reported as an error rather than ending the process
+func (c *CronTab) TestOpenCrontableFile() {
+	loc := filepath.Join(c.T().TempDir(), "crontab")
+	c.Require().NoError(os.WriteFile(loc, []byte("0 9 * *\n"), 0o600))
+	_, err := OpenCrontableFile(loc)
+	c.Assert().ErrorIs(err, ErrFieldCount)
+
+	c.Require().NoError(os.WriteFile(loc, []byte("# comment\n0 9 * * 6\n"), 0o600))
+	read, err := OpenCrontableFile(loc)
+	c.Require().NoError(err)
+	c.Assert().Equal(CronRead("0 9 * * 6"), *read)
+}
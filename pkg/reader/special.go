@@ -0,0 +1,78 @@
+package reader
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Quartz special terms a FieldSpec may accept, combined as a bit set
+const (
+	// SpecialsDayOfMonth accepts the Quartz "L", "L-n", "LW" and "nW" terms in a day of month field
+	SpecialsDayOfMonth = 1 << iota
+	// SpecialsDayOfWeek accepts the Quartz "L", "nL" and "n#m" terms in a day of week field
+	SpecialsDayOfWeek
+)
+
+// parseSpecial parses the Quartz special terms accepted by the field:
+//
+//	L    the last day of the month, or Saturday in the day of week field
+//	L-n  n days before the last day of the month
+//	LW   the last weekday of the month
+//	nW   the weekday nearest to day n of the month, without crossing into another month
+//	nL   the last day n of the week in the month, such as 6L for the last Friday
+//	n#m  the m-th day n of the week in the month, such as 6#3 for the third Friday
+//
+// It reports false when the term is not one of these, leaving it to parseTerm.
+func parseSpecial(term string, b FieldSpec) (Span, bool, error) {
+	upper := strings.ToUpper(term)
+	if b.Specials&SpecialsDayOfMonth != 0 {
+		switch {
+		case upper == "L":
+			return Span{Kind: DelimLast}, true, nil
+		case upper == "LW":
+			return Span{Kind: DelimLastWeekday}, true, nil
+		case strings.HasPrefix(upper, "L-"):
+			offset, err := strconv.Atoi(upper[2:])
+			if err != nil || !canBeNumber(upper[2:]) || offset < 1 || offset > b.High-1 {
+				return Span{}, true, errorAt(2, len(upper)-2, errorf(ErrOutOfRange, "offset %q of %q not within acceptable bounds 1-%d", upper[2:], term, b.High-1))
+			}
+			return Span{Kind: DelimLast, Offset: offset}, true, nil
+		case strings.HasSuffix(upper, "W") && len(upper) > 1:
+			v, err := parseValue(upper[:len(upper)-1], b)
+			if err != nil {
+				return Span{}, true, errorAt(0, len(upper)-1, err)
+			}
+			return Span{Low: v, High: v, Kind: DelimWeekday}, true, nil
+		}
+	}
+
+	if b.Specials&SpecialsDayOfWeek != 0 {
+		switch {
+		case upper == "L":
+			return Span{Low: b.High, High: b.High, Kind: DelimNone}, true, nil
+		case strings.HasSuffix(upper, "L") && len(upper) > 1:
+			v, err := parseValue(upper[:len(upper)-1], b)
+			if err != nil {
+				return Span{}, true, errorAt(0, len(upper)-1, err)
+			}
+			return Span{Low: v, High: v, Kind: DelimLast}, true, nil
+		case strings.Contains(upper, "#"):
+			day, nth, _ := strings.Cut(upper, "#")
+			v, err := parseValue(day, b)
+			if err != nil {
+				return Span{}, true, errorAt(0, len(day), err)
+			}
+			n, err := strconv.Atoi(nth)
+			if err != nil || !canBeNumber(nth) || n < 1 || n > 5 {
+				return Span{}, true, errorAt(len(day)+1, len(nth), errorf(ErrOutOfRange, "occurrence %q of %q not within acceptable bounds 1-5", nth, term))
+			}
+			return Span{Low: v, High: v, Nth: n, Kind: DelimNth}, true, nil
+		}
+	}
+	return Span{}, false, nil
+}
+
+// isSpecial reports whether the delimiter kind is one of the Quartz special terms, whose values depend on the calendar rather than on the field alone
+func isSpecial(kind int) bool {
+	return kind == DelimLast || kind == DelimWeekday || kind == DelimLastWeekday || kind == DelimNth
+}